@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/eastnine90/gbgen/internal/config"
+	"github.com/eastnine90/gbgen/internal/httpcache"
+	"github.com/spf13/cobra"
+)
+
+func newCacheCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the on-disk cache of GrowthBook responses",
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "clear",
+		Short: "Remove all cached GrowthBook responses",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.Load(config.LoadOptions{
+				ConfigPath: flagConfigPath,
+				EnvPrefix:  "GBGEN",
+			})
+			if err != nil {
+				return err
+			}
+
+			dir, err := httpcache.ResolveDir(cfg.Cache.Dir)
+			if err != nil {
+				return err
+			}
+			if err := httpcache.Clear(dir); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "cleared cache %s\n", dir)
+			return nil
+		},
+	})
+
+	return cmd
+}
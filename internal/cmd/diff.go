@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/eastnine90/gbgen/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+func newDiffCmd() *cobra.Command {
+	var (
+		sources sourceFlags
+		format  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Compare the generated feature constants with the features in GrowthBook",
+		Long: `Parses the generated code in generator.outputDir and compares its feature constants with the
+features GrowthBook has now: features added or removed, value types changed, deprecations gained
+or lost, and renamed identifiers. Nothing is written.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "text" && format != "json" {
+				return fmt.Errorf("--format must be text or json, got %q", format)
+			}
+
+			ctx := cmd.Context()
+			cfg, err := loadGeneratorConfig(ctx, sources.overrides(cmd))
+			if err != nil {
+				return err
+			}
+
+			mainName := cfg.Generator.OutputFileName()
+			old, err := generator.ReadGeneratedFeatures(cfg.Generator.OutputDir, mainName)
+			if errors.Is(err, fs.ErrNotExist) {
+				return fmt.Errorf("%s does not exist; run gbgen generate first", filepath.Join(cfg.Generator.OutputDir, mainName))
+			}
+			if err != nil {
+				return err
+			}
+
+			g, err := generator.NewGenerator(cfg)
+			if err != nil {
+				return err
+			}
+			current, err := g.Features(ctx)
+			if err != nil {
+				return err
+			}
+
+			d := generator.DiffFeatures(old, current)
+			if format == "json" {
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(d)
+			}
+			return d.WriteText(cmd.OutOrStdout())
+		},
+	}
+
+	sources.register(cmd)
+	cmd.Flags().StringVar(&format, "format", "text", "Output format: text|json")
+
+	return cmd
+}
@@ -2,6 +2,8 @@
 //
 // The root command wires configuration loading and subcommands such as:
 // - generate
+// - diff
 // - init
 // - version
+// - cache clear
 package cmd
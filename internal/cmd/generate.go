@@ -1,6 +1,9 @@
 package cmd
 
 import (
+	"context"
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 
@@ -9,43 +12,188 @@ import (
 	"github.com/spf13/cobra"
 )
 
-const outputFileName = "features.gen.go"
+// sourceFlags are the flags that select which features are generated, shared by generate and diff.
+type sourceFlags struct {
+	source    string
+	fromFile  string
+	clientKey string
+	projects  []string
+	names     []string
+	noCache   bool
+}
+
+func (f *sourceFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.source, "source", "", "Feature source: api|file|sdk (defaults to file when --from-file is set)")
+	cmd.Flags().StringVar(&f.clientKey, "client-key", "", "SDK connection client key: limits generation to the features it serves")
+	cmd.Flags().StringSliceVar(&f.projects, "project", nil, "GrowthBook project ID to generate from (repeatable; replaces growthbook.projectIDs)")
+	cmd.Flags().StringSliceVar(&f.names, "project-name", nil, "GrowthBook project name to generate from, resolved to its ID (repeatable; replaces growthbook.projectNames)")
+	cmd.Flags().BoolVar(&f.noCache, "no-cache", false, "Bypass the on-disk response cache")
+	cmd.Flags().StringVar(&f.fromFile, "from-file", "", "Read features from a saved snapshot file instead of the GrowthBook API")
+}
+
+// overrides returns the config overrides for the flags set on cmd.
+func (f *sourceFlags) overrides(cmd *cobra.Command) config.Overrides {
+	var overrides config.Overrides
+	if cmd.Flags().Changed("source") {
+		overrides.Source = &f.source
+	}
+	if cmd.Flags().Changed("client-key") {
+		overrides.ClientKey = &f.clientKey
+	}
+	if cmd.Flags().Changed("project") {
+		overrides.ProjectIDs = f.projects
+	}
+	if cmd.Flags().Changed("project-name") {
+		overrides.ProjectNames = f.names
+	}
+	if f.noCache {
+		disabled := false
+		overrides.CacheEnabled = &disabled
+	}
+	if cmd.Flags().Changed("from-file") {
+		overrides.FromFile = &f.fromFile
+		if overrides.Source == nil {
+			fileSource := config.SourceFile
+			overrides.Source = &fileSource
+		}
+	}
+	return overrides
+}
+
+// loadGeneratorConfig loads and validates the config and, for the api source, resolves the API key. The other
+// sources never call the REST API, so growthbook.apiKeyFile is not read and growthbook.apiKeyCommand not run.
+func loadGeneratorConfig(ctx context.Context, overrides config.Overrides) (config.Config, error) {
+	cfg, err := config.Load(config.LoadOptions{
+		ConfigPath: flagConfigPath,
+		EnvPrefix:  "GBGEN",
+		Overrides:  overrides,
+	})
+	if err != nil {
+		return config.Config{}, err
+	}
+	// validate config
+	if err := cfg.Validate(); err != nil {
+		return config.Config{}, err
+	}
+
+	if cfg.Generator.SourceMode() != config.SourceAPI {
+		return cfg, nil
+	}
+	apiKey, err := cfg.GrowthBook.ResolveAPIKey(ctx)
+	if err != nil {
+		return config.Config{}, err
+	}
+	cfg.GrowthBook.APIKey = apiKey
+	return cfg, nil
+}
 
 func newGenerateCmd() *cobra.Command {
-	return &cobra.Command{
+	var (
+		sources  sourceFlags
+		check    bool
+		toStdout bool
+		output   string
+	)
+
+	cmd := &cobra.Command{
 		Use:   "generate",
 		Short: "Generate Go types from GrowthBook features",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cfg, err := config.Load(config.LoadOptions{
-				ConfigPath: flagConfigPath,
-				EnvPrefix:  "GBGEN",
-			})
-			if err != nil {
-				return err
+			overrides := sources.overrides(cmd)
+			if output == "-" {
+				toStdout = true
+			} else if output != "" {
+				if toStdout {
+					return fmt.Errorf("-o %s writes to a file and cannot be combined with --stdout", output)
+				}
+				dir, file := filepath.Split(output)
+				if file == "" {
+					return fmt.Errorf("-o %s is a directory; give a .go file path, or - for stdout", output)
+				}
+				if filepath.Ext(file) != ".go" {
+					return fmt.Errorf("-o %s must be a .go file, or - for stdout", output)
+				}
+				if dir == "" {
+					dir = "."
+				}
+				overrides.OutputDir = &dir
+				overrides.OutputFile = &file
 			}
-			// validate config
-			if err := cfg.Validate(); err != nil {
-				return err
+			if toStdout && check {
+				return fmt.Errorf("--check compares files on disk and cannot be combined with --stdout")
 			}
 
 			ctx := cmd.Context()
-			g, err := generator.NewGenerator(cfg)
+			cfg, err := loadGeneratorConfig(ctx, overrides)
 			if err != nil {
 				return err
 			}
+			if toStdout && cfg.Generator.Split != "" {
+				return fmt.Errorf("--stdout writes a single file; generator.split is %q", cfg.Generator.Split)
+			}
 
-			src, err := g.Generate(ctx)
+			g, err := generator.NewGenerator(cfg)
 			if err != nil {
 				return err
 			}
 
-			if err := os.MkdirAll(cfg.Generator.OutputDir, 0o755); err != nil {
+			if toStdout {
+				return g.GenerateTo(ctx, cmd.OutOrStdout())
+			}
+
+			files, err := g.GenerateFiles(ctx)
+			if err != nil {
 				return err
 			}
+			if check {
+				return checkFiles(cmd.OutOrStdout(), cfg.Generator.OutputDir, files)
+			}
+			return writeFiles(cfg.Generator.OutputDir, files)
+		},
+	}
 
-			outPath := filepath.Join(cfg.Generator.OutputDir, outputFileName)
+	sources.register(cmd)
+	cmd.Flags().BoolVar(&check, "check", false, "Compare the generated code with the files on disk without writing; print a diff and fail when they differ")
+	cmd.Flags().BoolVar(&toStdout, "stdout", false, "Write the generated code to stdout instead of generator.outputDir")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Write the generated code to this file instead of generator.outputDir/generator.outputFile (- for stdout)")
 
-			return os.WriteFile(outPath, src, 0o644)
-		},
+	return cmd
+}
+
+// checkFiles prints the diff between files and dir to w, and fails when there is one.
+func checkFiles(w io.Writer, dir string, files []generator.OutputFile) error {
+	diff, err := generator.Drift(dir, files)
+	if err != nil {
+		return err
+	}
+	if diff == "" {
+		return nil
+	}
+	if _, err := io.WriteString(w, diff); err != nil {
+		return err
+	}
+	return fmt.Errorf("generated code in %s is out of date; run gbgen generate", dir)
+}
+
+// writeFiles writes files to dir and removes the split files of an earlier run that were not written again, as
+// those would declare the features that moved to another file a second time.
+func writeFiles(dir string, files []generator.OutputFile) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	stale, err := generator.StaleFiles(dir, files)
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		if err := os.WriteFile(filepath.Join(dir, f.Name), f.Src, 0o644); err != nil {
+			return err
+		}
+	}
+	for _, name := range stale {
+		if err := os.Remove(filepath.Join(dir, name)); err != nil {
+			return err
+		}
 	}
+	return nil
 }
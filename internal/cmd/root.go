@@ -26,6 +26,8 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&flagConfigPath, "config", "", "Path to config file (json|yaml|toml)")
 
 	rootCmd.AddCommand(newGenerateCmd())
+	rootCmd.AddCommand(newDiffCmd())
 	rootCmd.AddCommand(newVersionCmd())
 	rootCmd.AddCommand(newInitCmd())
+	rootCmd.AddCommand(newCacheCmd())
 }
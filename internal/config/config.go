@@ -1,5 +1,12 @@
 package config
 
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+)
+
 // Config is the single merged configuration for gbgen.
 //
 // NOTE: This is intentionally "definition-only" for now (no validation, no loading).
@@ -7,17 +14,219 @@ package config
 type Config struct {
 	GrowthBook GrowthBookConfig `json:"growthbook" yaml:"growthbook" toml:"growthbook"`
 	Generator  GeneratorConfig  `json:"generator"  yaml:"generator"  toml:"generator"`
+	Cache      CacheConfig      `json:"cache"      yaml:"cache"      toml:"cache"`
 }
 
 type GrowthBookConfig struct {
-	APIBaseURL string  `json:"apiBaseURL" yaml:"apiBaseURL" toml:"apiBaseURL" validate:"required,url"`
-	APIKey     string  `json:"apiKey"     yaml:"apiKey"     toml:"apiKey"     validate:"required"`
-	ProjectID  *string `json:"projectID"  yaml:"projectID"  toml:"projectID"`
+	APIBaseURL    string   `json:"apiBaseURL"    yaml:"apiBaseURL"    toml:"apiBaseURL"    validate:"required,url"`
+	APIPath       string   `json:"apiPath"       yaml:"apiPath"       toml:"apiPath"`
+	APIKey        string   `json:"apiKey"        yaml:"apiKey"        toml:"apiKey"`
+	APIKeyFile    string   `json:"apiKeyFile"    yaml:"apiKeyFile"    toml:"apiKeyFile"`
+	APIKeyCommand string   `json:"apiKeyCommand" yaml:"apiKeyCommand" toml:"apiKeyCommand"`
+	ProjectID     *string  `json:"projectID"     yaml:"projectID"     toml:"projectID"`
+	ProjectIDs    []string `json:"projectIDs"    yaml:"projectIDs"    toml:"projectIDs"`
+	ProjectNames  []string `json:"projectNames"  yaml:"projectNames"  toml:"projectNames"`
+	ClientKey     string   `json:"clientKey"     yaml:"clientKey"     toml:"clientKey"`
+	DecryptionKey string   `json:"decryptionKey" yaml:"decryptionKey" toml:"decryptionKey"`
+	PageSize      int      `json:"pageSize"      yaml:"pageSize"      toml:"pageSize"      validate:"gte=0,lte=100"`
+	Concurrency   int      `json:"concurrency"   yaml:"concurrency"   toml:"concurrency"   validate:"gte=0"`
+
+	Auth  AuthConfig  `json:"auth"  yaml:"auth"  toml:"auth"`
+	Retry RetryConfig `json:"retry" yaml:"retry" toml:"retry"`
+	HTTP  HTTPConfig  `json:"http"  yaml:"http"  toml:"http"`
+}
+
+// AuthConfig controls how the API key is sent to the GrowthBook REST API.
+type AuthConfig struct {
+	// Method is "bearer" (Authorization: Bearer <key>) or "basic" (the key as the basic auth username).
+	Method string `json:"method" yaml:"method" toml:"method" validate:"omitempty,oneof=bearer basic"`
+}
+
+// Authentication methods (growthbook.auth.method).
+const (
+	AuthBearer = "bearer"
+	AuthBasic  = "basic"
+)
+
+// RetryConfig controls retries of failed GrowthBook requests (429 and transient 5xx / network errors).
+// Waits grow exponentially from InitialBackoff up to MaxBackoff, with jitter; Retry-After and
+// rate-limit reset headers take precedence but are also capped at MaxBackoff.
+type RetryConfig struct {
+	MaxAttempts    int      `json:"maxAttempts"    yaml:"maxAttempts"    toml:"maxAttempts"    validate:"gte=0"`
+	InitialBackoff Duration `json:"initialBackoff" yaml:"initialBackoff" toml:"initialBackoff"`
+	MaxBackoff     Duration `json:"maxBackoff"     yaml:"maxBackoff"     toml:"maxBackoff"`
+}
+
+// HTTPConfig controls the HTTP client used to talk to GrowthBook.
+type HTTPConfig struct {
+	// Timeout bounds each request attempt, including reading the response body.
+	Timeout Duration `json:"timeout" yaml:"timeout" toml:"timeout"`
+	// ProxyURL is used for all requests; empty means HTTPS_PROXY / HTTP_PROXY / NO_PROXY from the environment.
+	ProxyURL string `json:"proxyURL" yaml:"proxyURL" toml:"proxyURL" validate:"omitempty,url"`
+	// CABundle is a PEM file of extra root certificates, trusted in addition to the system pool.
+	CABundle string `json:"caBundle" yaml:"caBundle" toml:"caBundle"`
+	// ClientCertFile and ClientKeyFile are a PEM certificate and key for mutual TLS.
+	ClientCertFile string `json:"clientCertFile" yaml:"clientCertFile" toml:"clientCertFile"`
+	ClientKeyFile  string `json:"clientKeyFile"  yaml:"clientKeyFile"  toml:"clientKeyFile"`
+	// Headers are added to every request. Authorization and User-Agent are set by gbgen and cannot be overridden here.
+	Headers map[string]string `json:"headers" yaml:"headers" toml:"headers"`
+	// UserAgent replaces the default "gbgen/<version>" User-Agent.
+	UserAgent string `json:"userAgent" yaml:"userAgent" toml:"userAgent"`
 }
 
 type GeneratorConfig struct {
-	OutputDir         string `json:"outputDir"         yaml:"outputDir"         toml:"outputDir"         validate:"required"`
-	PackageName       string `json:"packageName"       yaml:"packageName"       toml:"packageName"       validate:"required"`
-	EmitTypedFeatures bool   `json:"emitTypedFeatures" yaml:"emitTypedFeatures" toml:"emitTypedFeatures"`
-	EmitFeatureList   bool   `json:"emitFeatureList"   yaml:"emitFeatureList"   toml:"emitFeatureList"`
+	OutputDir          string   `json:"outputDir"          yaml:"outputDir"          toml:"outputDir"          validate:"required"`
+	OutputFile         string   `json:"outputFile"         yaml:"outputFile"         toml:"outputFile"`
+	Split              string   `json:"split"              yaml:"split"              toml:"split"              validate:"omitempty,oneof=project tag"`
+	PackageName        string   `json:"packageName"        yaml:"packageName"        toml:"packageName"        validate:"required"`
+	EmitTypedFeatures  bool     `json:"emitTypedFeatures"  yaml:"emitTypedFeatures"  toml:"emitTypedFeatures"`
+	EmitFeatureList    bool     `json:"emitFeatureList"    yaml:"emitFeatureList"    toml:"emitFeatureList"`
+	EmitSavedGroups    bool     `json:"emitSavedGroups"    yaml:"emitSavedGroups"    toml:"emitSavedGroups"`
+	EmitAttributes     bool     `json:"emitAttributes"     yaml:"emitAttributes"     toml:"emitAttributes"`
+	EmitExperiments    bool     `json:"emitExperiments"    yaml:"emitExperiments"    toml:"emitExperiments"`
+	EmitEnvironments   bool     `json:"emitEnvironments"   yaml:"emitEnvironments"   toml:"emitEnvironments"`
+	EmitProjects       bool     `json:"emitProjects"       yaml:"emitProjects"       toml:"emitProjects"`
+	EmitManifest       bool     `json:"emitManifest"       yaml:"emitManifest"       toml:"emitManifest"`
+	Source             string   `json:"source"             yaml:"source"             toml:"source"             validate:"omitempty,oneof=api file sdk"`
+	FromFile           string   `json:"fromFile"           yaml:"fromFile"           toml:"fromFile"`
+	OnDuplicateFeature string   `json:"onDuplicateFeature" yaml:"onDuplicateFeature" toml:"onDuplicateFeature" validate:"omitempty,oneof=first error"`
+	IncludeTags        []string `json:"includeTags"        yaml:"includeTags"        toml:"includeTags"`
+	ExcludeTags        []string `json:"excludeTags"        yaml:"excludeTags"        toml:"excludeTags"`
+	IncludePatterns    []string `json:"includePatterns"    yaml:"includePatterns"    toml:"includePatterns"`
+	ExcludePatterns    []string `json:"excludePatterns"    yaml:"excludePatterns"    toml:"excludePatterns"`
+	StripPrefix        string   `json:"stripPrefix"        yaml:"stripPrefix"        toml:"stripPrefix"`
+	ArchivedPolicy     string   `json:"archivedPolicy"     yaml:"archivedPolicy"     toml:"archivedPolicy"     validate:"omitempty,oneof=skip deprecate include"`
+	Environments       []string `json:"environments"       yaml:"environments"       toml:"environments"`
+	InactivePolicy     string   `json:"inactivePolicy"     yaml:"inactivePolicy"     toml:"inactivePolicy"     validate:"omitempty,oneof=skip deprecate include"`
+}
+
+// Archived feature policies (generator.archivedPolicy).
+const (
+	// ArchivedSkip leaves archived features out of the generated code.
+	ArchivedSkip = "skip"
+	// ArchivedDeprecate generates archived features with a "Deprecated: archived in GrowthBook" marker.
+	ArchivedDeprecate = "deprecate"
+	// ArchivedInclude generates archived features like any other feature.
+	ArchivedInclude = "include"
+)
+
+// Inactive feature policies (generator.inactivePolicy), for features enabled in none of the
+// environments that count (generator.environments, or all environments if unset).
+const (
+	// InactiveSkip leaves inactive features out of the generated code.
+	InactiveSkip = "skip"
+	// InactiveDeprecate generates inactive features with a "Deprecated:" marker.
+	InactiveDeprecate = "deprecate"
+	// InactiveInclude generates inactive features like any other feature.
+	InactiveInclude = "include"
+)
+
+// Output layouts (generator.split). The default ("") writes every feature to generator.outputFile.
+const (
+	// SplitProject writes the features of each GrowthBook project to their own file.
+	SplitProject = "project"
+	// SplitTag writes the features of each tag to their own file.
+	SplitTag = "tag"
+)
+
+// Duplicate feature policies (generator.onDuplicateFeature).
+const (
+	// DuplicateFirst keeps the definition from the project listed first in growthbook.projectIDs.
+	DuplicateFirst = "first"
+	// DuplicateError fails generation.
+	DuplicateError = "error"
+)
+
+// Projects returns the project IDs to generate from, in configured order and without duplicates:
+// growthbook.projectIDs followed by growthbook.projectID. An empty result means "all projects".
+// growthbook.projectNames are not included; the generator resolves them and adds them to ProjectIDs.
+func (g GrowthBookConfig) Projects() []string {
+	var out []string
+	seen := map[string]struct{}{}
+	add := func(id string) {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			return
+		}
+		if _, ok := seen[id]; ok {
+			return
+		}
+		seen[id] = struct{}{}
+		out = append(out, id)
+	}
+	for _, id := range g.ProjectIDs {
+		add(id)
+	}
+	if g.ProjectID != nil {
+		add(*g.ProjectID)
+	}
+	return out
+}
+
+// CacheConfig controls the optional on-disk cache of GrowthBook responses.
+// An empty Dir means "<user cache dir>/gbgen".
+type CacheConfig struct {
+	Enabled bool     `json:"enabled" yaml:"enabled" toml:"enabled"`
+	Dir     string   `json:"dir"     yaml:"dir"     toml:"dir"`
+	TTL     Duration `json:"ttl"     yaml:"ttl"     toml:"ttl"`
+}
+
+// Feature definition sources.
+const (
+	// SourceAPI lists features from the GrowthBook REST API (requires a secret key).
+	SourceAPI = "api"
+	// SourceFile reads features from a saved snapshot file (generator.fromFile).
+	SourceFile = "file"
+	// SourceSDK fetches the public SDK payload for growthbook.clientKey (no secret key needed).
+	SourceSDK = "sdk"
+)
+
+// SourceMode returns the effective feature source.
+// An explicit generator.source wins; otherwise a configured fromFile implies SourceFile.
+func (g GeneratorConfig) SourceMode() string {
+	if g.Source != "" {
+		return g.Source
+	}
+	if g.FromFile != "" {
+		return SourceFile
+	}
+	return SourceAPI
+}
+
+// DefaultOutputFile is the generated file name used when generator.outputFile is empty.
+const DefaultOutputFile = "features.gen.go"
+
+// OutputFileName returns generator.outputFile, or DefaultOutputFile when it is empty.
+func (g GeneratorConfig) OutputFileName() string {
+	if g.OutputFile != "" {
+		return g.OutputFile
+	}
+	return DefaultOutputFile
+}
+
+// Hash returns a digest of the settings that shape the generated code, recorded in its manifest
+// (generator.emitManifest). Credentials, local paths and transport settings are left out, so the same
+// config hashes alike on every machine.
+func (c Config) Hash() string {
+	gen := c.Generator
+	gen.OutputDir = ""
+	gen.FromFile = ""
+	// Marshalling strings, bools and string slices cannot fail.
+	src, _ := json.Marshal(struct {
+		APIBaseURL   string          `json:"apiBaseURL"`
+		APIPath      string          `json:"apiPath"`
+		Projects     []string        `json:"projects"`
+		ProjectNames []string        `json:"projectNames"`
+		ClientKey    string          `json:"clientKey"`
+		Generator    GeneratorConfig `json:"generator"`
+	}{
+		APIBaseURL:   c.GrowthBook.APIBaseURL,
+		APIPath:      c.GrowthBook.APIPath,
+		Projects:     c.GrowthBook.Projects(),
+		ProjectNames: c.GrowthBook.ProjectNames,
+		ClientKey:    c.GrowthBook.ClientKey,
+		Generator:    gen,
+	})
+	sum := sha256.Sum256(src)
+	return "sha256:" + hex.EncodeToString(sum[:])
 }
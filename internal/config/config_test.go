@@ -0,0 +1,41 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConfigHash(t *testing.T) {
+	base := Defaults()
+	hash := base.Hash()
+	if !strings.HasPrefix(hash, "sha256:") || len(hash) != len("sha256:")+64 {
+		t.Fatalf("Hash() = %q, want sha256:<hex>", hash)
+	}
+
+	// Credentials, local paths and transport settings do not shape the generated code.
+	same := Defaults()
+	same.GrowthBook.APIKey = "secret_abc"
+	same.GrowthBook.DecryptionKey = "key"
+	same.GrowthBook.PageSize = 10
+	same.Generator.OutputDir = "/home/me/project/internal/flags"
+	same.Generator.FromFile = "/tmp/snapshot.json"
+	same.Cache.Enabled = !base.Cache.Enabled
+	if got := same.Hash(); got != hash {
+		t.Fatalf("Hash() changed with credentials, paths or transport settings: %q != %q", got, hash)
+	}
+
+	project := "prj_web"
+	for name, change := range map[string]func(*Config){
+		"packageName":  func(c *Config) { c.Generator.PackageName = "flags" },
+		"typed":        func(c *Config) { c.Generator.EmitTypedFeatures = !c.Generator.EmitTypedFeatures },
+		"projectID":    func(c *Config) { c.GrowthBook.ProjectID = &project },
+		"projectNames": func(c *Config) { c.GrowthBook.ProjectNames = []string{"Web app"} },
+		"includeTags":  func(c *Config) { c.Generator.IncludeTags = []string{"web"} },
+	} {
+		cfg := Defaults()
+		change(&cfg)
+		if cfg.Hash() == hash {
+			t.Errorf("Hash() did not change with %s", name)
+		}
+	}
+}
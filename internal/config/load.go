@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Overrides represents explicit values (e.g., CLI flags) that override config/env/defaults.
@@ -13,11 +14,34 @@ import (
 type Overrides struct {
 	APIBaseURL        *string
 	APIKey            *string
+	APIKeyFile        *string
+	APIKeyCommand     *string
+	AuthMethod        *string
 	ProjectID         *string
+	ProjectIDs        []string
+	ProjectNames      []string
+	ClientKey         *string
+	DecryptionKey     *string
+	PageSize          *int
+	Concurrency       *int
+	MaxAttempts       *int
+	HTTPTimeout       *Duration
 	OutputDir         *string
+	OutputFile        *string
+	Split             *string
 	PackageName       *string
 	EmitTypedFeatures *bool
 	EmitFeatureList   *bool
+	EmitSavedGroups   *bool
+	EmitAttributes    *bool
+	EmitExperiments   *bool
+	EmitEnvironments  *bool
+	EmitProjects      *bool
+	EmitManifest      *bool
+	Source            *string
+	FromFile          *string
+	CacheEnabled      *bool
+	CacheDir          *string
 }
 
 // Load builds the final config using the following precedence (highest wins):
@@ -54,15 +78,44 @@ type LoadOptions struct {
 func Defaults() Config {
 	return Config{
 		GrowthBook: GrowthBookConfig{
-			APIBaseURL: "https://api.growthbook.io",
-			APIKey:     "",
-			ProjectID:  nil,
+			APIBaseURL:  "https://api.growthbook.io",
+			APIPath:     "/api/v1",
+			APIKey:      "",
+			ProjectID:   nil,
+			PageSize:    100,
+			Concurrency: 4,
+			Auth: AuthConfig{
+				Method: AuthBearer,
+			},
+			Retry: RetryConfig{
+				MaxAttempts:    4,
+				InitialBackoff: Duration(500 * time.Millisecond),
+				MaxBackoff:     Duration(30 * time.Second),
+			},
+			HTTP: HTTPConfig{
+				Timeout: Duration(30 * time.Second),
+			},
 		},
 		Generator: GeneratorConfig{
-			OutputDir:         "./internal/growthbooktypes",
-			PackageName:       "growthbooktypes",
-			EmitTypedFeatures: false,
-			EmitFeatureList:   false,
+			OutputDir:          "./internal/growthbooktypes",
+			OutputFile:         DefaultOutputFile,
+			PackageName:        "growthbooktypes",
+			EmitTypedFeatures:  false,
+			EmitFeatureList:    false,
+			EmitSavedGroups:    false,
+			EmitAttributes:     false,
+			EmitExperiments:    false,
+			EmitEnvironments:   false,
+			EmitProjects:       false,
+			EmitManifest:       false,
+			OnDuplicateFeature: DuplicateFirst,
+			ArchivedPolicy:     ArchivedDeprecate,
+			InactivePolicy:     InactiveDeprecate,
+		},
+		Cache: CacheConfig{
+			Enabled: false,
+			Dir:     "",
+			TTL:     Duration(time.Minute),
 		},
 	}
 }
@@ -96,17 +149,75 @@ func merge(base, overlay Config) Config {
 	if overlay.GrowthBook.APIBaseURL != "" {
 		out.GrowthBook.APIBaseURL = overlay.GrowthBook.APIBaseURL
 	}
-	if overlay.GrowthBook.APIKey != "" {
-		out.GrowthBook.APIKey = overlay.GrowthBook.APIKey
+	overlayAPIKeySources(&out.GrowthBook, overlay.GrowthBook.APIKey, overlay.GrowthBook.APIKeyFile, overlay.GrowthBook.APIKeyCommand)
+	if overlay.GrowthBook.APIPath != "" {
+		out.GrowthBook.APIPath = overlay.GrowthBook.APIPath
+	}
+	if overlay.GrowthBook.Auth.Method != "" {
+		out.GrowthBook.Auth.Method = overlay.GrowthBook.Auth.Method
 	}
 	if overlay.GrowthBook.ProjectID != nil {
 		out.GrowthBook.ProjectID = overlay.GrowthBook.ProjectID
 	}
+	if overlay.GrowthBook.ProjectIDs != nil {
+		out.GrowthBook.ProjectIDs = overlay.GrowthBook.ProjectIDs
+	}
+	if overlay.GrowthBook.ProjectNames != nil {
+		out.GrowthBook.ProjectNames = overlay.GrowthBook.ProjectNames
+	}
+	if overlay.GrowthBook.ClientKey != "" {
+		out.GrowthBook.ClientKey = overlay.GrowthBook.ClientKey
+	}
+	if overlay.GrowthBook.DecryptionKey != "" {
+		out.GrowthBook.DecryptionKey = overlay.GrowthBook.DecryptionKey
+	}
+	if overlay.GrowthBook.PageSize != 0 {
+		out.GrowthBook.PageSize = overlay.GrowthBook.PageSize
+	}
+	if overlay.GrowthBook.Concurrency != 0 {
+		out.GrowthBook.Concurrency = overlay.GrowthBook.Concurrency
+	}
+	if overlay.GrowthBook.Retry.MaxAttempts != 0 {
+		out.GrowthBook.Retry.MaxAttempts = overlay.GrowthBook.Retry.MaxAttempts
+	}
+	if overlay.GrowthBook.Retry.InitialBackoff != 0 {
+		out.GrowthBook.Retry.InitialBackoff = overlay.GrowthBook.Retry.InitialBackoff
+	}
+	if overlay.GrowthBook.Retry.MaxBackoff != 0 {
+		out.GrowthBook.Retry.MaxBackoff = overlay.GrowthBook.Retry.MaxBackoff
+	}
+	if overlay.GrowthBook.HTTP.Timeout != 0 {
+		out.GrowthBook.HTTP.Timeout = overlay.GrowthBook.HTTP.Timeout
+	}
+	if overlay.GrowthBook.HTTP.ProxyURL != "" {
+		out.GrowthBook.HTTP.ProxyURL = overlay.GrowthBook.HTTP.ProxyURL
+	}
+	if overlay.GrowthBook.HTTP.CABundle != "" {
+		out.GrowthBook.HTTP.CABundle = overlay.GrowthBook.HTTP.CABundle
+	}
+	if overlay.GrowthBook.HTTP.ClientCertFile != "" {
+		out.GrowthBook.HTTP.ClientCertFile = overlay.GrowthBook.HTTP.ClientCertFile
+	}
+	if overlay.GrowthBook.HTTP.ClientKeyFile != "" {
+		out.GrowthBook.HTTP.ClientKeyFile = overlay.GrowthBook.HTTP.ClientKeyFile
+	}
+	if overlay.GrowthBook.HTTP.Headers != nil {
+		out.GrowthBook.HTTP.Headers = overlay.GrowthBook.HTTP.Headers
+	}
+	if overlay.GrowthBook.HTTP.UserAgent != "" {
+		out.GrowthBook.HTTP.UserAgent = overlay.GrowthBook.HTTP.UserAgent
+	}
 
 	// Generator
 	if overlay.Generator.OutputDir != "" {
 		out.Generator.OutputDir = overlay.Generator.OutputDir
 	}
+	if overlay.Generator.OutputFile != "" {
+		out.Generator.OutputFile = overlay.Generator.OutputFile
+	}
+	if overlay.Generator.Split != "" {
+		out.Generator.Split = overlay.Generator.Split
+	}
 	if overlay.Generator.PackageName != "" {
 		out.Generator.PackageName = overlay.Generator.PackageName
 	}
@@ -118,10 +229,82 @@ func merge(base, overlay Config) Config {
 	if overlay.Generator.EmitFeatureList {
 		out.Generator.EmitFeatureList = true
 	}
+	if overlay.Generator.EmitSavedGroups {
+		out.Generator.EmitSavedGroups = true
+	}
+	if overlay.Generator.EmitAttributes {
+		out.Generator.EmitAttributes = true
+	}
+	if overlay.Generator.EmitExperiments {
+		out.Generator.EmitExperiments = true
+	}
+	if overlay.Generator.EmitEnvironments {
+		out.Generator.EmitEnvironments = true
+	}
+	if overlay.Generator.EmitProjects {
+		out.Generator.EmitProjects = true
+	}
+	if overlay.Generator.EmitManifest {
+		out.Generator.EmitManifest = true
+	}
+	if overlay.Generator.Source != "" {
+		out.Generator.Source = overlay.Generator.Source
+	}
+	if overlay.Generator.FromFile != "" {
+		out.Generator.FromFile = overlay.Generator.FromFile
+	}
+	if overlay.Generator.OnDuplicateFeature != "" {
+		out.Generator.OnDuplicateFeature = overlay.Generator.OnDuplicateFeature
+	}
+	if overlay.Generator.ArchivedPolicy != "" {
+		out.Generator.ArchivedPolicy = overlay.Generator.ArchivedPolicy
+	}
+	if overlay.Generator.IncludePatterns != nil {
+		out.Generator.IncludePatterns = overlay.Generator.IncludePatterns
+	}
+	if overlay.Generator.ExcludePatterns != nil {
+		out.Generator.ExcludePatterns = overlay.Generator.ExcludePatterns
+	}
+	if overlay.Generator.StripPrefix != "" {
+		out.Generator.StripPrefix = overlay.Generator.StripPrefix
+	}
+	if overlay.Generator.Environments != nil {
+		out.Generator.Environments = overlay.Generator.Environments
+	}
+	if overlay.Generator.InactivePolicy != "" {
+		out.Generator.InactivePolicy = overlay.Generator.InactivePolicy
+	}
+	if overlay.Generator.IncludeTags != nil {
+		out.Generator.IncludeTags = overlay.Generator.IncludeTags
+	}
+	if overlay.Generator.ExcludeTags != nil {
+		out.Generator.ExcludeTags = overlay.Generator.ExcludeTags
+	}
+
+	// Cache
+	if overlay.Cache.Enabled {
+		out.Cache.Enabled = true
+	}
+	if overlay.Cache.Dir != "" {
+		out.Cache.Dir = overlay.Cache.Dir
+	}
+	if overlay.Cache.TTL != 0 {
+		out.Cache.TTL = overlay.Cache.TTL
+	}
 
 	return out
 }
 
+// overlayAPIKeySources applies the API key sources of a higher layer. A layer that sets any of growthbook.apiKey,
+// apiKeyFile and apiKeyCommand replaces all three, so GBGEN_API_KEY wins over an apiKeyFile from the config file.
+// Several sources set in the same layer are kept for Validate to reject.
+func overlayAPIKeySources(g *GrowthBookConfig, key, file, command string) {
+	if strings.TrimSpace(key) == "" && strings.TrimSpace(file) == "" && strings.TrimSpace(command) == "" {
+		return
+	}
+	g.APIKey, g.APIKeyFile, g.APIKeyCommand = key, file, command
+}
+
 func applyEnv(cfg Config, prefix string) Config {
 	p := strings.TrimSpace(prefix)
 	if p == "" {
@@ -132,16 +315,74 @@ func applyEnv(cfg Config, prefix string) Config {
 	if v := os.Getenv(key("API_BASE_URL")); v != "" {
 		cfg.GrowthBook.APIBaseURL = v
 	}
-	if v := os.Getenv(key("API_KEY")); v != "" {
-		cfg.GrowthBook.APIKey = v
+	overlayAPIKeySources(&cfg.GrowthBook, os.Getenv(key("API_KEY")), os.Getenv(key("API_KEY_FILE")), os.Getenv(key("API_KEY_COMMAND")))
+	if v := os.Getenv(key("API_PATH")); v != "" {
+		cfg.GrowthBook.APIPath = v
+	}
+	if v := os.Getenv(key("AUTH_METHOD")); v != "" {
+		cfg.GrowthBook.Auth.Method = v
 	}
 	if v := os.Getenv(key("PROJECT_ID")); v != "" {
 		tmp := v
 		cfg.GrowthBook.ProjectID = &tmp
 	}
+	if v := os.Getenv(key("PROJECT_IDS")); v != "" {
+		cfg.GrowthBook.ProjectIDs = splitList(v)
+	}
+	if v := os.Getenv(key("PROJECT_NAMES")); v != "" {
+		cfg.GrowthBook.ProjectNames = splitList(v)
+	}
+	if v := os.Getenv(key("CLIENT_KEY")); v != "" {
+		cfg.GrowthBook.ClientKey = v
+	}
+	if v := os.Getenv(key("DECRYPTION_KEY")); v != "" {
+		cfg.GrowthBook.DecryptionKey = v
+	}
+	if v := os.Getenv(key("PAGE_SIZE")); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.GrowthBook.PageSize = n
+		}
+	}
+	if v := os.Getenv(key("CONCURRENCY")); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.GrowthBook.Concurrency = n
+		}
+	}
+	if v := os.Getenv(key("RETRY_MAX_ATTEMPTS")); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.GrowthBook.Retry.MaxAttempts = n
+		}
+	}
+	if v := os.Getenv(key("HTTP_TIMEOUT")); v != "" {
+		var d Duration
+		if err := d.UnmarshalText([]byte(v)); err == nil {
+			cfg.GrowthBook.HTTP.Timeout = d
+		}
+	}
+	if v := os.Getenv(key("HTTP_PROXY_URL")); v != "" {
+		cfg.GrowthBook.HTTP.ProxyURL = v
+	}
+	if v := os.Getenv(key("HTTP_CA_BUNDLE")); v != "" {
+		cfg.GrowthBook.HTTP.CABundle = v
+	}
+	if v := os.Getenv(key("HTTP_CLIENT_CERT_FILE")); v != "" {
+		cfg.GrowthBook.HTTP.ClientCertFile = v
+	}
+	if v := os.Getenv(key("HTTP_CLIENT_KEY_FILE")); v != "" {
+		cfg.GrowthBook.HTTP.ClientKeyFile = v
+	}
+	if v := os.Getenv(key("HTTP_USER_AGENT")); v != "" {
+		cfg.GrowthBook.HTTP.UserAgent = v
+	}
 	if v := os.Getenv(key("OUTPUT_DIR")); v != "" {
 		cfg.Generator.OutputDir = v
 	}
+	if v := os.Getenv(key("OUTPUT_FILE")); v != "" {
+		cfg.Generator.OutputFile = v
+	}
+	if v := os.Getenv(key("SPLIT")); v != "" {
+		cfg.Generator.Split = v
+	}
 	if v := os.Getenv(key("PACKAGE_NAME")); v != "" {
 		cfg.Generator.PackageName = v
 	}
@@ -155,6 +396,83 @@ func applyEnv(cfg Config, prefix string) Config {
 			cfg.Generator.EmitFeatureList = b
 		}
 	}
+	if v := os.Getenv(key("EMIT_SAVED_GROUPS")); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.Generator.EmitSavedGroups = b
+		}
+	}
+	if v := os.Getenv(key("EMIT_ATTRIBUTES")); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.Generator.EmitAttributes = b
+		}
+	}
+	if v := os.Getenv(key("EMIT_EXPERIMENTS")); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.Generator.EmitExperiments = b
+		}
+	}
+	if v := os.Getenv(key("EMIT_ENVIRONMENTS")); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.Generator.EmitEnvironments = b
+		}
+	}
+	if v := os.Getenv(key("EMIT_PROJECTS")); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.Generator.EmitProjects = b
+		}
+	}
+	if v := os.Getenv(key("EMIT_MANIFEST")); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.Generator.EmitManifest = b
+		}
+	}
+	if v := os.Getenv(key("SOURCE")); v != "" {
+		cfg.Generator.Source = v
+	}
+	if v := os.Getenv(key("FROM_FILE")); v != "" {
+		cfg.Generator.FromFile = v
+	}
+	if v := os.Getenv(key("ON_DUPLICATE_FEATURE")); v != "" {
+		cfg.Generator.OnDuplicateFeature = v
+	}
+	if v := os.Getenv(key("ARCHIVED_POLICY")); v != "" {
+		cfg.Generator.ArchivedPolicy = v
+	}
+	if v := os.Getenv(key("INCLUDE_PATTERNS")); v != "" {
+		cfg.Generator.IncludePatterns = splitList(v)
+	}
+	if v := os.Getenv(key("EXCLUDE_PATTERNS")); v != "" {
+		cfg.Generator.ExcludePatterns = splitList(v)
+	}
+	if v := os.Getenv(key("STRIP_PREFIX")); v != "" {
+		cfg.Generator.StripPrefix = v
+	}
+	if v := os.Getenv(key("ENVIRONMENTS")); v != "" {
+		cfg.Generator.Environments = splitList(v)
+	}
+	if v := os.Getenv(key("INACTIVE_POLICY")); v != "" {
+		cfg.Generator.InactivePolicy = v
+	}
+	if v := os.Getenv(key("INCLUDE_TAGS")); v != "" {
+		cfg.Generator.IncludeTags = splitList(v)
+	}
+	if v := os.Getenv(key("EXCLUDE_TAGS")); v != "" {
+		cfg.Generator.ExcludeTags = splitList(v)
+	}
+	if v := os.Getenv(key("CACHE")); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			cfg.Cache.Enabled = b
+		}
+	}
+	if v := os.Getenv(key("CACHE_DIR")); v != "" {
+		cfg.Cache.Dir = v
+	}
+	if v := os.Getenv(key("CACHE_TTL")); v != "" {
+		var d Duration
+		if err := d.UnmarshalText([]byte(v)); err == nil {
+			cfg.Cache.TTL = d
+		}
+	}
 
 	return cfg
 }
@@ -163,15 +481,56 @@ func applyOverrides(cfg Config, o Overrides) Config {
 	if o.APIBaseURL != nil {
 		cfg.GrowthBook.APIBaseURL = *o.APIBaseURL
 	}
-	if o.APIKey != nil {
-		cfg.GrowthBook.APIKey = *o.APIKey
+	if o.APIKey != nil || o.APIKeyFile != nil || o.APIKeyCommand != nil {
+		deref := func(p *string) string {
+			if p == nil {
+				return ""
+			}
+			return *p
+		}
+		cfg.GrowthBook.APIKey = deref(o.APIKey)
+		cfg.GrowthBook.APIKeyFile = deref(o.APIKeyFile)
+		cfg.GrowthBook.APIKeyCommand = deref(o.APIKeyCommand)
+	}
+	if o.AuthMethod != nil {
+		cfg.GrowthBook.Auth.Method = *o.AuthMethod
 	}
 	if o.ProjectID != nil {
 		cfg.GrowthBook.ProjectID = o.ProjectID
 	}
+	if o.ProjectIDs != nil {
+		cfg.GrowthBook.ProjectIDs = o.ProjectIDs
+	}
+	if o.ProjectNames != nil {
+		cfg.GrowthBook.ProjectNames = o.ProjectNames
+	}
+	if o.ClientKey != nil {
+		cfg.GrowthBook.ClientKey = *o.ClientKey
+	}
+	if o.DecryptionKey != nil {
+		cfg.GrowthBook.DecryptionKey = *o.DecryptionKey
+	}
+	if o.PageSize != nil {
+		cfg.GrowthBook.PageSize = *o.PageSize
+	}
+	if o.Concurrency != nil {
+		cfg.GrowthBook.Concurrency = *o.Concurrency
+	}
+	if o.MaxAttempts != nil {
+		cfg.GrowthBook.Retry.MaxAttempts = *o.MaxAttempts
+	}
+	if o.HTTPTimeout != nil {
+		cfg.GrowthBook.HTTP.Timeout = *o.HTTPTimeout
+	}
 	if o.OutputDir != nil {
 		cfg.Generator.OutputDir = *o.OutputDir
 	}
+	if o.OutputFile != nil {
+		cfg.Generator.OutputFile = *o.OutputFile
+	}
+	if o.Split != nil {
+		cfg.Generator.Split = *o.Split
+	}
 	if o.PackageName != nil {
 		cfg.Generator.PackageName = *o.PackageName
 	}
@@ -181,5 +540,46 @@ func applyOverrides(cfg Config, o Overrides) Config {
 	if o.EmitFeatureList != nil {
 		cfg.Generator.EmitFeatureList = *o.EmitFeatureList
 	}
+	if o.EmitSavedGroups != nil {
+		cfg.Generator.EmitSavedGroups = *o.EmitSavedGroups
+	}
+	if o.EmitAttributes != nil {
+		cfg.Generator.EmitAttributes = *o.EmitAttributes
+	}
+	if o.EmitExperiments != nil {
+		cfg.Generator.EmitExperiments = *o.EmitExperiments
+	}
+	if o.EmitEnvironments != nil {
+		cfg.Generator.EmitEnvironments = *o.EmitEnvironments
+	}
+	if o.EmitProjects != nil {
+		cfg.Generator.EmitProjects = *o.EmitProjects
+	}
+	if o.EmitManifest != nil {
+		cfg.Generator.EmitManifest = *o.EmitManifest
+	}
+	if o.Source != nil {
+		cfg.Generator.Source = *o.Source
+	}
+	if o.FromFile != nil {
+		cfg.Generator.FromFile = *o.FromFile
+	}
+	if o.CacheEnabled != nil {
+		cfg.Cache.Enabled = *o.CacheEnabled
+	}
+	if o.CacheDir != nil {
+		cfg.Cache.Dir = *o.CacheDir
+	}
 	return cfg
 }
+
+// splitList parses a comma-separated env value, dropping empty items.
+func splitList(v string) []string {
+	var out []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
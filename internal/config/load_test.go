@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -104,3 +105,65 @@ generator:
 		t.Fatalf("emitFeatureList = %v", got.Generator.EmitFeatureList)
 	}
 }
+
+func TestLoad_ProjectIDsFromEnv(t *testing.T) {
+	t.Setenv("GBGEN_PROJECT_ID", "prj_b")
+	t.Setenv("GBGEN_PROJECT_IDS", "prj_a, prj_b,,prj_c")
+	t.Setenv("GBGEN_PROJECT_NAMES", "Web app,Public API")
+
+	got, err := Load(LoadOptions{EnvPrefix: "GBGEN"})
+	if err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+	if projects := strings.Join(got.GrowthBook.Projects(), ","); projects != "prj_a,prj_b,prj_c" {
+		t.Fatalf("projects = %q", projects)
+	}
+	if names := strings.Join(got.GrowthBook.ProjectNames, "|"); names != "Web app|Public API" {
+		t.Fatalf("project names = %q", names)
+	}
+}
+
+func TestLoad_APIKeySources_HigherLayerReplaces(t *testing.T) {
+	tmp := t.TempDir()
+	cfgPath := filepath.Join(tmp, "gbgen.yaml")
+	if err := os.WriteFile(cfgPath, []byte(`
+growthbook:
+  apiKeyFile: /run/secrets/growthbook
+  apiKeyCommand: ""
+`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("GBGEN_API_KEY", "secret_env")
+	got, err := Load(LoadOptions{ConfigPath: cfgPath})
+	if err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+	if got.GrowthBook.APIKey != "secret_env" || got.GrowthBook.APIKeyFile != "" {
+		t.Fatalf("apiKey = %q, apiKeyFile = %q; want the env key to replace the file's apiKeyFile", got.GrowthBook.APIKey, got.GrowthBook.APIKeyFile)
+	}
+	if err := got.Validate(); err != nil {
+		t.Fatalf("Validate error: %v", err)
+	}
+
+	command := "op read op://ci/growthbook/key"
+	got, err = Load(LoadOptions{ConfigPath: cfgPath, Overrides: Overrides{APIKeyCommand: &command}})
+	if err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+	if got.GrowthBook.APIKeyCommand != command || got.GrowthBook.APIKey != "" || got.GrowthBook.APIKeyFile != "" {
+		t.Fatalf("got %+v; want only the overridden apiKeyCommand", got.GrowthBook)
+	}
+
+	// Several sources in the same layer are still rejected.
+	t.Setenv("GBGEN_API_KEY_FILE", "/run/secrets/other")
+	got, err = Load(LoadOptions{ConfigPath: cfgPath})
+	if err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+	err = got.Validate()
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	assertContains(t, err.Error(), "only one of growthbook.apiKey, growthbook.apiKeyFile may be set")
+}
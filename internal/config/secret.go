@@ -0,0 +1,76 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// apiKeySources returns the config keys of the API key sources that are set.
+func (g GrowthBookConfig) apiKeySources() []string {
+	var set []string
+	if strings.TrimSpace(g.APIKey) != "" {
+		set = append(set, "growthbook.apiKey")
+	}
+	if strings.TrimSpace(g.APIKeyFile) != "" {
+		set = append(set, "growthbook.apiKeyFile")
+	}
+	if strings.TrimSpace(g.APIKeyCommand) != "" {
+		set = append(set, "growthbook.apiKeyCommand")
+	}
+	return set
+}
+
+// ResolveAPIKey returns the API key from growthbook.apiKey, the file at growthbook.apiKeyFile, or the stdout of
+// growthbook.apiKeyCommand (at most one of them is set; see Validate). Surrounding whitespace is trimmed.
+//
+// Errors never include the key or the command's output.
+func (g GrowthBookConfig) ResolveAPIKey(ctx context.Context) (string, error) {
+	switch {
+	case strings.TrimSpace(g.APIKeyFile) != "":
+		b, err := os.ReadFile(g.APIKeyFile)
+		if err != nil {
+			return "", fmt.Errorf("growthbook.apiKeyFile: %w", err)
+		}
+		key := strings.TrimSpace(string(b))
+		if key == "" {
+			return "", fmt.Errorf("growthbook.apiKeyFile: %s is empty", g.APIKeyFile)
+		}
+		return key, nil
+
+	case strings.TrimSpace(g.APIKeyCommand) != "":
+		var stdout bytes.Buffer
+		cmd := shellCommand(ctx, g.APIKeyCommand)
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = &stdout
+		// Diagnostics (and prompts) of the command go to the terminal, never into gbgen errors.
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			var ee *exec.ExitError
+			if errors.As(err, &ee) {
+				return "", fmt.Errorf("growthbook.apiKeyCommand failed: %s", ee.ProcessState)
+			}
+			return "", fmt.Errorf("growthbook.apiKeyCommand failed: %w", err)
+		}
+		key := strings.TrimSpace(stdout.String())
+		if key == "" {
+			return "", errors.New("growthbook.apiKeyCommand printed nothing")
+		}
+		return key, nil
+
+	default:
+		return strings.TrimSpace(g.APIKey), nil
+	}
+}
+
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestResolveAPIKey_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gb-key")
+	if err := os.WriteFile(path, []byte("secret_fromfile\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := GrowthBookConfig{APIKeyFile: path}.ResolveAPIKey(context.Background())
+	if err != nil {
+		t.Fatalf("ResolveAPIKey error: %v", err)
+	}
+	if key != "secret_fromfile" {
+		t.Fatalf("key = %q", key)
+	}
+
+	if _, err := (GrowthBookConfig{APIKeyFile: path + ".missing"}).ResolveAPIKey(context.Background()); err == nil {
+		t.Fatal("expected error for missing file")
+	}
+}
+
+func TestResolveAPIKey_Command(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+
+	key, err := GrowthBookConfig{APIKeyCommand: "printf 'secret_fromcmd\\n'"}.ResolveAPIKey(context.Background())
+	if err != nil {
+		t.Fatalf("ResolveAPIKey error: %v", err)
+	}
+	if key != "secret_fromcmd" {
+		t.Fatalf("key = %q", key)
+	}
+
+	_, err = GrowthBookConfig{APIKeyCommand: "echo secret_leaked; exit 3"}.ResolveAPIKey(context.Background())
+	if err == nil {
+		t.Fatal("expected error for failing command")
+	}
+	if strings.Contains(err.Error(), "secret_leaked") {
+		t.Fatalf("error leaks command output: %v", err)
+	}
+	assertContains(t, err.Error(), "exit status 3")
+}
+
+func TestConfigValidate_APIKeySources(t *testing.T) {
+	cfg := Defaults()
+	cfg.GrowthBook.APIKeyFile = "/run/secrets/growthbook"
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	cfg.GrowthBook.APIKey = "secret_abc"
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	assertContains(t, err.Error(), "only one of growthbook.apiKey, growthbook.apiKeyFile may be set")
+	if strings.Contains(err.Error(), "secret_abc") {
+		t.Fatalf("error leaks the key: %v", err)
+	}
+
+	cfg.GrowthBook.APIKey = ""
+	cfg.GrowthBook.Auth.Method = "digest"
+	err = cfg.Validate()
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	assertContains(t, err.Error(), "growthbook.auth.method must be one of: bearer, basic")
+}
@@ -2,6 +2,9 @@ package config
 
 import (
 	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/go-playground/validator/v10"
@@ -27,14 +30,119 @@ func (e *ValidationError) Error() string {
 }
 
 // Validate validates the config using struct tags and returns a user-friendly error.
+//
+// Rules that depend on other fields (e.g. credentials required by the selected source)
+// are checked after the struct tags and reported in the same error.
 func (c Config) Validate() error {
+	var problems []string
+
 	v := validator.New(validator.WithRequiredStructEnabled())
 	if err := v.Struct(c); err != nil {
-		return humanizeValidationError(err)
+		herr := humanizeValidationError(err)
+		verr, ok := herr.(*ValidationError)
+		if !ok {
+			return herr
+		}
+		problems = append(problems, verr.Problems...)
+	}
+
+	problems = append(problems, c.sourceProblems()...)
+	problems = append(problems, c.patternProblems()...)
+	problems = append(problems, c.headerProblems()...)
+	if f := c.Generator.OutputFile; f != "" && (filepath.Base(f) != f || filepath.Ext(f) != ".go") {
+		problems = append(problems, fmt.Sprintf("generator.outputFile %q must be a .go file name without a directory (use generator.outputDir)", f))
+	}
+	if (c.GrowthBook.HTTP.ClientCertFile == "") != (c.GrowthBook.HTTP.ClientKeyFile == "") {
+		problems = append(problems, "growthbook.http.clientCertFile and growthbook.http.clientKeyFile must be set together")
+	}
+	if set := c.GrowthBook.apiKeySources(); len(set) > 1 {
+		problems = append(problems, fmt.Sprintf("only one of %s may be set", strings.Join(set, ", ")))
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
 	}
 	return nil
 }
 
+// sourceProblems reports settings required by the selected feature source.
+func (c Config) sourceProblems() []string {
+	var problems []string
+	switch c.Generator.SourceMode() {
+	case SourceAPI:
+		if len(c.GrowthBook.apiKeySources()) == 0 {
+			problems = append(problems, "growthbook.apiKey is required (or growthbook.apiKeyFile / growthbook.apiKeyCommand)")
+		}
+	case SourceFile:
+		if strings.TrimSpace(c.Generator.FromFile) == "" {
+			problems = append(problems, fmt.Sprintf("generator.fromFile is required when generator.source is %q", SourceFile))
+		}
+	case SourceSDK:
+		if strings.TrimSpace(c.GrowthBook.ClientKey) == "" {
+			problems = append(problems, fmt.Sprintf("growthbook.clientKey is required when generator.source is %q", SourceSDK))
+		}
+		if c.Generator.EmitEnvironments {
+			problems = append(problems, fmt.Sprintf("generator.emitEnvironments is not supported with generator.source %q (the SDK payload has no environments)", SourceSDK))
+		}
+	}
+	if c.Generator.SourceMode() != SourceAPI {
+		problems = append(problems, c.apiOnlyProblems()...)
+	}
+	return problems
+}
+
+// apiOnlyProblems reports settings that need the REST API but are used with another source.
+func (c Config) apiOnlyProblems() []string {
+	var problems []string
+	if c.Generator.EmitSavedGroups {
+		problems = append(problems, fmt.Sprintf("generator.emitSavedGroups requires generator.source %q", SourceAPI))
+	}
+	if c.Generator.EmitAttributes {
+		problems = append(problems, fmt.Sprintf("generator.emitAttributes requires generator.source %q", SourceAPI))
+	}
+	if c.Generator.EmitExperiments {
+		problems = append(problems, fmt.Sprintf("generator.emitExperiments requires generator.source %q", SourceAPI))
+	}
+	if c.Generator.EmitProjects {
+		problems = append(problems, fmt.Sprintf("generator.emitProjects requires generator.source %q", SourceAPI))
+	}
+	if len(c.GrowthBook.ProjectNames) > 0 {
+		problems = append(problems, fmt.Sprintf("growthbook.projectNames requires generator.source %q", SourceAPI))
+	}
+	return problems
+}
+
+// patternProblems reports feature ID patterns that are not valid regular expressions.
+func (c Config) patternProblems() []string {
+	var problems []string
+	check := func(key string, patterns []string) {
+		for _, p := range patterns {
+			if _, err := regexp.Compile(p); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %v", key, err))
+			}
+		}
+	}
+	check("generator.includePatterns", c.Generator.IncludePatterns)
+	check("generator.excludePatterns", c.Generator.ExcludePatterns)
+	return problems
+}
+
+// headerProblems reports growthbook.http.headers that gbgen sets itself and that would silently replace the
+// credentials or the User-Agent.
+func (c Config) headerProblems() []string {
+	var problems []string
+	for name := range c.GrowthBook.HTTP.Headers {
+		switch {
+		case strings.EqualFold(name, "Authorization"):
+			problems = append(problems, fmt.Sprintf("growthbook.http.headers cannot set %q (use growthbook.apiKey and growthbook.auth.method)", name))
+		case strings.EqualFold(name, "User-Agent"):
+			problems = append(problems, fmt.Sprintf("growthbook.http.headers cannot set %q (use growthbook.http.userAgent)", name))
+		}
+	}
+	sort.Strings(problems)
+	return problems
+}
+
 func humanizeValidationError(err error) error {
 	var ve validator.ValidationErrors
 	if !strings.Contains(err.Error(), "ValidationErrors") {
@@ -55,6 +163,12 @@ func humanizeValidationError(err error) error {
 			problems = append(problems, fmt.Sprintf("%s is required", path))
 		case "url":
 			problems = append(problems, fmt.Sprintf("%s must be a valid URL (e.g. https://api.growthbook.io)", path))
+		case "gte":
+			problems = append(problems, fmt.Sprintf("%s must be >= %s", path, fe.Param()))
+		case "lte":
+			problems = append(problems, fmt.Sprintf("%s must be <= %s", path, fe.Param()))
+		case "oneof":
+			problems = append(problems, fmt.Sprintf("%s must be one of: %s", path, strings.ReplaceAll(fe.Param(), " ", ", ")))
 		default:
 			problems = append(problems, fmt.Sprintf("%s is invalid (%s)", path, fe.Tag()))
 		}
@@ -81,10 +195,35 @@ func toConfigPath(structNamespace string) string {
 	s = strings.ReplaceAll(s, "GrowthBook.", "growthbook.")
 	s = strings.ReplaceAll(s, "Generator.", "generator.")
 	s = strings.ReplaceAll(s, "APIBaseURL", "apiBaseURL")
+	s = strings.ReplaceAll(s, "APIPath", "apiPath")
 	s = strings.ReplaceAll(s, "APIKey", "apiKey")
 	s = strings.ReplaceAll(s, "ProjectID", "projectID")
+	s = strings.ReplaceAll(s, "ProjectNames", "projectNames")
+	s = strings.ReplaceAll(s, "ClientKey", "clientKey")
+	s = strings.ReplaceAll(s, "DecryptionKey", "decryptionKey")
+	s = strings.ReplaceAll(s, "PageSize", "pageSize")
+	s = strings.ReplaceAll(s, "Concurrency", "concurrency")
+	s = strings.ReplaceAll(s, "Auth.", "auth.")
+	s = strings.ReplaceAll(s, "Method", "method")
+	s = strings.ReplaceAll(s, "Retry.", "retry.")
+	s = strings.ReplaceAll(s, "MaxAttempts", "maxAttempts")
+	s = strings.ReplaceAll(s, "HTTP.", "http.")
+	s = strings.ReplaceAll(s, "ProxyURL", "proxyURL")
 	s = strings.ReplaceAll(s, "OutputDir", "outputDir")
+	s = strings.ReplaceAll(s, "OutputFile", "outputFile")
+	s = strings.ReplaceAll(s, "Split", "split")
 	s = strings.ReplaceAll(s, "PackageName", "packageName")
+	s = strings.ReplaceAll(s, "EmitSavedGroups", "emitSavedGroups")
+	s = strings.ReplaceAll(s, "EmitAttributes", "emitAttributes")
+	s = strings.ReplaceAll(s, "EmitExperiments", "emitExperiments")
+	s = strings.ReplaceAll(s, "EmitEnvironments", "emitEnvironments")
+	s = strings.ReplaceAll(s, "EmitProjects", "emitProjects")
+	s = strings.ReplaceAll(s, "EmitManifest", "emitManifest")
+	s = strings.ReplaceAll(s, "Source", "source")
+	s = strings.ReplaceAll(s, "FromFile", "fromFile")
+	s = strings.ReplaceAll(s, "OnDuplicateFeature", "onDuplicateFeature")
+	s = strings.ReplaceAll(s, "ArchivedPolicy", "archivedPolicy")
+	s = strings.ReplaceAll(s, "InactivePolicy", "inactivePolicy")
 
 	return s
 }
@@ -58,3 +58,81 @@ func assertContains(t *testing.T, s, substr string) {
 		t.Fatalf("expected %q to contain %q", s, substr)
 	}
 }
+
+func TestConfigValidate_FileSource_NoAPIKeyRequired(t *testing.T) {
+	cfg := Defaults()
+	cfg.Generator.FromFile = "./features.json"
+
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+
+	cfg.Generator.FromFile = ""
+	cfg.Generator.Source = SourceFile
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	assertContains(t, err.Error(), "generator.fromFile is required")
+}
+
+func TestConfigValidate_SDKSource_RequiresClientKey(t *testing.T) {
+	cfg := Defaults()
+	cfg.Generator.Source = SourceSDK
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	assertContains(t, err.Error(), "growthbook.clientKey is required")
+	if strings.Contains(err.Error(), "apiKey") {
+		t.Fatalf("did not expect apiKey to be required for the sdk source: %v", err)
+	}
+
+	cfg.GrowthBook.ClientKey = "sdk-abc123"
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+}
+
+func TestConfigValidate_InvalidIDPattern(t *testing.T) {
+	cfg := Defaults()
+	cfg.GrowthBook.APIKey = "secret_abc"
+	cfg.Generator.ExcludePatterns = []string{`^payments\.(`}
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	assertContains(t, err.Error(), "generator.excludePatterns: error parsing regexp")
+}
+
+func TestConfigValidate_ReservedHeaders(t *testing.T) {
+	cfg := Defaults()
+	cfg.GrowthBook.APIKey = "secret_abc"
+	cfg.GrowthBook.HTTP.Headers = map[string]string{"authorization": "Bearer other", "User-Agent": "x", "X-Tenant": "platform"}
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	assertContains(t, err.Error(), `growthbook.http.headers cannot set "authorization"`)
+	assertContains(t, err.Error(), `growthbook.http.headers cannot set "User-Agent" (use growthbook.http.userAgent)`)
+	if strings.Contains(err.Error(), "X-Tenant") {
+		t.Fatalf("unexpected problem with X-Tenant: %v", err)
+	}
+}
+
+func TestConfigValidate_OutputFileAndSplit(t *testing.T) {
+	cfg := Defaults()
+	cfg.GrowthBook.APIKey = "secret_abc"
+	cfg.Generator.OutputFile = "gen/features.go"
+	cfg.Generator.Split = "team"
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	assertContains(t, err.Error(), `generator.outputFile "gen/features.go" must be a .go file name without a directory`)
+	assertContains(t, err.Error(), "generator.split")
+}
@@ -0,0 +1,199 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/eastnine90/gbgen/internal/growthbookapi"
+)
+
+const growthbookImport = "github.com/growthbook/growthbook-golang"
+
+type attributeMeta struct {
+	Property      string
+	Datatype      growthbookapi.AttributeDatatype
+	Description   string
+	HashAttribute bool
+	Enum          []string
+}
+
+// fetchAttributes lists the organization's attribute schema, without archived attributes and attributes
+// limited to other projects.
+func (g *Generator) fetchAttributes(ctx context.Context, projects []string) ([]attributeMeta, error) {
+	resp, err := g.api.ListAttributesWithResponse(ctx)
+	if err != nil {
+		return nil, redactTransportError(err, g.secrets()...)
+	}
+	if resp == nil {
+		return nil, fmt.Errorf("list attributes: empty response")
+	}
+	if resp.JSON200 == nil {
+		return nil, fmt.Errorf("list attributes: %w", newAPIError(resp.HTTPResponse, resp.Body, restAPIHint, g.secrets()...))
+	}
+
+	seen := map[string]struct{}{}
+	var out []attributeMeta
+	for _, a := range resp.JSON200.Attributes {
+		if a.Property == "" || (a.Archived != nil && *a.Archived) {
+			continue
+		}
+		if a.Projects != nil && !inAnyProject(*a.Projects, projects) {
+			continue
+		}
+		if _, ok := seen[a.Property]; ok {
+			continue
+		}
+		seen[a.Property] = struct{}{}
+
+		m := attributeMeta{Property: a.Property, Datatype: a.Datatype}
+		if a.Description != nil {
+			m.Description = *a.Description
+		}
+		if a.HashAttribute != nil {
+			m.HashAttribute = *a.HashAttribute
+		}
+		if a.Datatype == growthbookapi.AttributeDatatypeEnum && a.Enum != nil {
+			for _, v := range strings.Split(*a.Enum, ",") {
+				if v = strings.TrimSpace(v); v != "" {
+					m.Enum = append(m.Enum, v)
+				}
+			}
+		}
+		out = append(out, m)
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Property < out[j].Property })
+	return out, nil
+}
+
+// attributeField is an Attributes struct field derived from an attribute.
+type attributeField struct {
+	attributeMeta
+	Name     string
+	GoType   string // field type
+	EnumType string // named string type of enum attributes
+}
+
+// attributeFieldName returns the exported field name of an attribute. Names that would not start with an upper-case
+// letter ("2faEnabled") are prefixed with "Attr" so the field is exported.
+func attributeFieldName(property string) string {
+	name := toExportedIdentifier(property)
+	if r, _ := utf8.DecodeRuneInString(name); !unicode.IsUpper(r) {
+		name = "Attr" + name
+	}
+	return name
+}
+
+// renderAttributes declares an Attributes struct with a ToGrowthBook converter, and a named string type with
+// constants for every enum attribute. Unset (nil) fields are left out of the converted attributes.
+func renderAttributes(attrs []attributeMeta) (declSection, error) {
+	// Reserve the method name so an attribute called "toGrowthBook" cannot shadow it.
+	nameCounts := map[string]int{"ToGrowthBook": 1}
+	fields := make([]attributeField, 0, len(attrs))
+	for _, a := range attrs {
+		name := attributeFieldName(a.Property)
+		nameCounts[name]++
+		if n := nameCounts[name]; n > 1 {
+			name = fmt.Sprintf("%s_%d", name, n)
+		}
+
+		f := attributeField{attributeMeta: a, Name: name}
+		switch a.Datatype {
+		case growthbookapi.AttributeDatatypeBoolean:
+			f.GoType = "*bool"
+		case growthbookapi.AttributeDatatypeString, growthbookapi.AttributeDatatypeSecureString:
+			f.GoType = "*string"
+		case growthbookapi.AttributeDatatypeNumber:
+			f.GoType = "*float64"
+		case growthbookapi.AttributeDatatypeEnum:
+			f.EnumType = "Attribute" + name
+			f.GoType = "*" + f.EnumType
+		case growthbookapi.AttributeDatatypeString1, growthbookapi.AttributeDatatypeSecureString1:
+			f.GoType = "[]string"
+		case growthbookapi.AttributeDatatypeNumber1:
+			f.GoType = "[]float64"
+		default:
+			return declSection{}, fmt.Errorf("attribute %q: unsupported datatype %q", a.Property, string(a.Datatype))
+		}
+		fields = append(fields, f)
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Attributes are the targeting attributes defined in GrowthBook.\n")
+	fmt.Fprintf(&b, "// Nil fields are left out of ToGrowthBook.\n")
+	fmt.Fprintf(&b, "type Attributes struct {\n")
+	for i, f := range fields {
+		if i > 0 {
+			fmt.Fprintf(&b, "\n")
+		}
+		fmt.Fprintf(&b, "\t// %s is the %q attribute.", f.Name, f.Property)
+		if f.HashAttribute {
+			fmt.Fprintf(&b, " It is a hash attribute (used to assign variations).")
+		}
+		fmt.Fprintf(&b, "\n")
+		if desc := strings.TrimSpace(f.Description); desc != "" {
+			fmt.Fprintf(&b, "\t//\n")
+			for _, line := range strings.Split(desc, "\n") {
+				if line = strings.TrimSpace(line); line != "" {
+					fmt.Fprintf(&b, "\t// %s\n", line)
+				}
+			}
+		}
+		fmt.Fprintf(&b, "\t%s %s\n", f.Name, f.GoType)
+	}
+	fmt.Fprintf(&b, "}\n\n")
+
+	fmt.Fprintf(&b, "// ToGrowthBook converts a to the attributes map used by the GrowthBook SDK.\n")
+	fmt.Fprintf(&b, "func (a Attributes) ToGrowthBook() growthbook.Attributes {\n")
+	fmt.Fprintf(&b, "\tattrs := growthbook.Attributes{}\n")
+	for _, f := range fields {
+		fmt.Fprintf(&b, "\tif a.%s != nil {\n", f.Name)
+		switch {
+		case f.EnumType != "":
+			fmt.Fprintf(&b, "\t\tattrs[%q] = string(*a.%s)\n", f.Property, f.Name)
+		case strings.HasPrefix(f.GoType, "*"):
+			fmt.Fprintf(&b, "\t\tattrs[%q] = *a.%s\n", f.Property, f.Name)
+		default:
+			fmt.Fprintf(&b, "\t\tattrs[%q] = a.%s\n", f.Property, f.Name)
+		}
+		fmt.Fprintf(&b, "\t}\n")
+	}
+	fmt.Fprintf(&b, "\treturn attrs\n")
+	fmt.Fprintf(&b, "}\n")
+
+	// Enum constants are named after their type and value, so reserve the type names of all enums first: "US" of
+	// "country" must not take the name of the "countryUS" enum type.
+	declCounts := map[string]int{"Attributes": 1}
+	for _, f := range fields {
+		if f.EnumType != "" {
+			declCounts[f.EnumType]++
+		}
+	}
+	for _, f := range fields {
+		if f.EnumType == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "\n// %s is a value of the %q enum attribute.\n", f.EnumType, f.Property)
+		fmt.Fprintf(&b, "type %s string\n", f.EnumType)
+		if len(f.Enum) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\nconst (\n")
+		for _, v := range f.Enum {
+			name := f.EnumType + toExportedIdentifier(v)
+			declCounts[name]++
+			if n := declCounts[name]; n > 1 {
+				name = fmt.Sprintf("%s_%d", name, n)
+			}
+			fmt.Fprintf(&b, "\t%s %s = %q\n", name, f.EnumType, v)
+		}
+		fmt.Fprintf(&b, ")\n")
+	}
+
+	return declSection{Imports: []string{growthbookImport}, Body: b.Bytes()}, nil
+}
@@ -0,0 +1,100 @@
+package generator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eastnine90/gbgen/internal/config"
+	"github.com/eastnine90/gbgen/internal/growthbookapi"
+)
+
+func TestGeneratorGenerate_Attributes(t *testing.T) {
+	ptr := func(s string) *string { return &s }
+	yes := true
+	attrs := &growthbookapi.ListAttributesResponse{}
+	attrs.JSON200 = &struct {
+		Attributes []growthbookapi.Attribute `json:"attributes"`
+	}{
+		Attributes: []growthbookapi.Attribute{
+			{Property: "id", Datatype: growthbookapi.AttributeDatatypeString, HashAttribute: &yes, Description: ptr("Logged-in user ID.")},
+			{Property: "plan", Datatype: growthbookapi.AttributeDatatypeEnum, Enum: ptr("free, pro,enterprise")},
+			{Property: "age", Datatype: growthbookapi.AttributeDatatypeNumber},
+			{Property: "tags", Datatype: growthbookapi.AttributeDatatypeString1},
+			{Property: "legacy", Datatype: growthbookapi.AttributeDatatypeBoolean, Archived: &yes},
+			{Property: "otherOnly", Datatype: growthbookapi.AttributeDatatypeBoolean, Projects: &[]string{"prj_other"}},
+		},
+	}
+
+	mock := &mockFeaturesAPI{
+		t: t,
+		featuresRespByOffset: map[int32]*growthbookapi.ListFeaturesResponse{
+			0: listFeaturesResponse([]growthbookapi.Feature{{
+				Id:           "checkout",
+				Environments: map[string]growthbookapi.FeatureEnvironment{"production": {Enabled: true}},
+				ValueType:    growthbookapi.FeatureValueTypeBoolean,
+			}}, nil),
+		},
+		attributesResp: attrs,
+	}
+	project := "prj_web"
+	cfg := config.Config{
+		GrowthBook: config.GrowthBookConfig{ProjectID: &project},
+		Generator:  config.GeneratorConfig{PackageName: "features", EmitAttributes: true},
+	}
+	src, err := (&Generator{api: mock, config: cfg}).Generate(context.Background())
+	if err != nil {
+		t.Fatalf("Generate error: %v", err)
+	}
+	assertGofmtIdempotent(t, src)
+	out := string(src)
+
+	assertContains(t, out, "\"github.com/growthbook/growthbook-golang\"")
+	assertContains(t, out, "type Attributes struct {")
+	assertContains(t, out, "\t// Id is the \"id\" attribute. It is a hash attribute (used to assign variations).\n\t//\n\t// Logged-in user ID.\n\tId *string\n")
+	assertContains(t, out, "\tAge *float64\n")
+	assertContains(t, out, "\tPlan *AttributePlan\n")
+	assertContains(t, out, "\tTags []string\n")
+	assertContains(t, out, "\t\tattrs[\"plan\"] = string(*a.Plan)\n")
+	assertContains(t, out, "\t\tattrs[\"tags\"] = a.Tags\n")
+	assertContains(t, out, "type AttributePlan string")
+	assertContains(t, out, "AttributePlanFree       AttributePlan = \"free\"")
+	assertContains(t, out, "AttributePlanEnterprise AttributePlan = \"enterprise\"")
+	assertNotContains(t, out, "Legacy")
+	assertNotContains(t, out, "OtherOnly")
+}
+
+func TestRenderAttributes_FieldNamesStartWithALetter(t *testing.T) {
+	sec, err := renderAttributes([]attributeMeta{
+		{Property: "2faEnabled", Datatype: growthbookapi.AttributeDatatypeBoolean},
+		{Property: "_country", Datatype: growthbookapi.AttributeDatatypeString},
+		{Property: "123", Datatype: growthbookapi.AttributeDatatypeEnum, Enum: []string{"a"}},
+	})
+	if err != nil {
+		t.Fatalf("renderAttributes error: %v", err)
+	}
+	out := string(sec.Body)
+	assertContains(t, out, "\tAttr2faEnabled *bool\n")
+	assertContains(t, out, "\t\tattrs[\"2faEnabled\"] = *a.Attr2faEnabled\n")
+	assertContains(t, out, "\tCountry *string\n")
+	assertContains(t, out, "\tAttr123 *AttributeAttr123\n")
+}
+
+func TestRenderAttributes_EnumNamesAreUnique(t *testing.T) {
+	sec, err := renderAttributes([]attributeMeta{
+		{Property: "country", Datatype: growthbookapi.AttributeDatatypeEnum, Enum: []string{"US", "us"}},
+		{Property: "countryUS", Datatype: growthbookapi.AttributeDatatypeEnum, Enum: []string{"yes"}},
+	})
+	if err != nil {
+		t.Fatalf("renderAttributes error: %v", err)
+	}
+	src, err := renderFeatureKeysGo("features", nil, false, sec)
+	if err != nil {
+		t.Fatalf("render error: %v", err)
+	}
+	assertUniqueDecls(t, src)
+	out := string(src)
+	assertContains(t, out, "type AttributeCountryUS string\n")
+	assertContains(t, out, "\tAttributeCountryUS_2 AttributeCountry = \"US\"\n")
+	assertContains(t, out, "\tAttributeCountryUs   AttributeCountry = \"us\"\n")
+	assertContains(t, out, "\tAttributeCountryUSYes AttributeCountryUS = \"yes\"\n")
+}
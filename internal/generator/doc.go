@@ -1,6 +1,9 @@
 // Package generator fetches feature definitions from the GrowthBook API and renders Go source.
 //
+// Feature definitions can also be read from a saved snapshot file (generator.fromFile), which
+// feeds the same pipeline and yields byte-identical output for the same features.
+//
 // Output behavior:
-// - Always writes a single file named "features.gen.go" (the file content varies by config).
+// - Writes generator.outputFile ("features.gen.go" by default), plus one file per project or tag with generator.split.
 // - Generated identifiers are derived from feature IDs and deduplicated when needed.
 package generator
@@ -0,0 +1,132 @@
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// generatedHeader marks files written by gbgen; only those are treated as stale split files.
+var generatedHeader = []byte("// Code generated by gbgen ")
+
+// StaleFiles lists the split files in dir left over from an earlier run: files named after the main file
+// (features_*.gen.go next to features.gen.go) that carry the gbgen header but are not among files. files[0] is the
+// main file, as returned by GenerateFiles.
+func StaleFiles(dir string, files []OutputFile) ([]string, error) {
+	if len(files) == 0 {
+		return nil, nil
+	}
+	names, err := generatedSplitFiles(dir, files[0].Name)
+	if err != nil {
+		return nil, err
+	}
+	written := map[string]struct{}{}
+	for _, f := range files {
+		written[f.Name] = struct{}{}
+	}
+	var out []string
+	for _, name := range names {
+		if _, ok := written[name]; !ok {
+			out = append(out, name)
+		}
+	}
+	return out, nil
+}
+
+// generatedSplitFiles lists the files in dir that are named after the main file (features_*.gen.go next to
+// features.gen.go) and carry the gbgen header. A missing dir has none.
+func generatedSplitFiles(dir, mainName string) ([]string, error) {
+	base, ext, ok := strings.Cut(mainName, ".")
+	if !ok {
+		return nil, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var out []string
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || name == mainName || !strings.HasPrefix(name, base+"_") || !strings.HasSuffix(name, "."+ext) {
+			continue
+		}
+		src, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Contains(src, generatedHeader) {
+			out = append(out, name)
+		}
+	}
+	return out, nil
+}
+
+// Drift compares the generated files with those in dir and returns a unified diff of what generating would
+// change, including stale split files it would remove. It returns "" when dir is up to date.
+func Drift(dir string, files []OutputFile) (string, error) {
+	var b strings.Builder
+	for _, f := range files {
+		current, err := os.ReadFile(filepath.Join(dir, f.Name))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+		from := filepath.ToSlash(filepath.Join(dir, f.Name))
+		if err != nil {
+			from = "/dev/null"
+		}
+		if err := writeUnifiedDiff(&b, current, f.Src, from, filepath.ToSlash(filepath.Join(dir, f.Name))); err != nil {
+			return "", err
+		}
+	}
+
+	stale, err := StaleFiles(dir, files)
+	if err != nil {
+		return "", err
+	}
+	for _, name := range stale {
+		current, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return "", err
+		}
+		if err := writeUnifiedDiff(&b, current, nil, filepath.ToSlash(filepath.Join(dir, name)), "/dev/null"); err != nil {
+			return "", err
+		}
+	}
+	return b.String(), nil
+}
+
+// writeUnifiedDiff writes the diff from a to b, or nothing when they are equal.
+func writeUnifiedDiff(w *strings.Builder, a, b []byte, from, to string) error {
+	if bytes.Equal(a, b) {
+		return nil
+	}
+	return difflib.WriteUnifiedDiff(w, difflib.UnifiedDiff{
+		A:        splitDiffLines(a),
+		B:        splitDiffLines(b),
+		FromFile: from,
+		ToFile:   to,
+		Context:  3,
+	})
+}
+
+// splitDiffLines splits src into lines that keep their "\n". A missing or empty file has no lines.
+func splitDiffLines(src []byte) []string {
+	if len(src) == 0 {
+		return nil
+	}
+	lines := strings.SplitAfter(string(src), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	} else {
+		lines[len(lines)-1] += "\n\\ No newline at end of file\n"
+	}
+	return lines
+}
@@ -0,0 +1,58 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDrift(t *testing.T) {
+	dir := t.TempDir()
+	files := []OutputFile{
+		{Name: "features.gen.go", Src: []byte("// Code generated by gbgen v1. DO NOT EDIT.\n\npackage features\n\nconst A = 1\n")},
+		{Name: "features_web.gen.go", Src: []byte("// Code generated by gbgen v1. DO NOT EDIT.\n\npackage features\n")},
+	}
+
+	diff, err := Drift(dir, files)
+	if err != nil {
+		t.Fatalf("Drift error: %v", err)
+	}
+	assertContains(t, diff, "--- /dev/null\n+++ "+filepath.ToSlash(filepath.Join(dir, "features.gen.go"))+"\n")
+	assertContains(t, diff, "+const A = 1\n")
+
+	for _, f := range files {
+		if err := os.WriteFile(filepath.Join(dir, f.Name), f.Src, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if diff, err := Drift(dir, files); err != nil || diff != "" {
+		t.Fatalf("Drift on up-to-date dir = %q, %v; want no diff", diff, err)
+	}
+
+	// A stale split file and a hand-written file next to the generated ones.
+	if err := os.WriteFile(filepath.Join(dir, "features_old.gen.go"), []byte("// Code generated by gbgen v0. DO NOT EDIT.\n\npackage features\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "features_extra.gen.go"), []byte("package features\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	stale, err := StaleFiles(dir, files)
+	if err != nil {
+		t.Fatalf("StaleFiles error: %v", err)
+	}
+	if got := strings.Join(stale, ","); got != "features_old.gen.go" {
+		t.Fatalf("StaleFiles = %v, want [features_old.gen.go]", stale)
+	}
+
+	files[0].Src = []byte("// Code generated by gbgen v1. DO NOT EDIT.\n\npackage features\n\nconst A = 2\n")
+	diff, err = Drift(dir, files)
+	if err != nil {
+		t.Fatalf("Drift error: %v", err)
+	}
+	assertContains(t, diff, "-const A = 1\n+const A = 2\n")
+	assertContains(t, diff, "+++ /dev/null\n")
+	assertContains(t, diff, "-// Code generated by gbgen v0. DO NOT EDIT.\n")
+	assertNotContains(t, diff, "features_web.gen.go")
+	assertNotContains(t, diff, "features_extra.gen.go")
+}
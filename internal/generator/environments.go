@@ -0,0 +1,87 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/eastnine90/gbgen/internal/config"
+)
+
+type environmentMeta struct {
+	ID          string
+	Description string
+}
+
+// loadEnvironments collects the environments of the generated features. With the REST API source, environments
+// from /environments are added, without those limited to other projects.
+func (g *Generator) loadEnvironments(ctx context.Context, features []featureMeta, projects []string) ([]environmentMeta, error) {
+	byID := map[string]environmentMeta{}
+	for _, f := range features {
+		for id := range f.Environments {
+			byID[id] = environmentMeta{ID: id}
+		}
+	}
+
+	if g.config.Generator.SourceMode() == config.SourceAPI {
+		resp, err := g.api.ListEnvironmentsWithResponse(ctx)
+		if err != nil {
+			return nil, redactTransportError(err, g.secrets()...)
+		}
+		if resp == nil {
+			return nil, fmt.Errorf("list environments: empty response")
+		}
+		if resp.JSON200 == nil {
+			return nil, fmt.Errorf("list environments: %w", newAPIError(resp.HTTPResponse, resp.Body, restAPIHint, g.secrets()...))
+		}
+		for _, e := range resp.JSON200.Environments {
+			if e.Id == "" || !inAnyProject(e.Projects, projects) {
+				continue
+			}
+			byID[e.Id] = environmentMeta{ID: e.Id, Description: e.Description}
+		}
+	}
+
+	out := make([]environmentMeta, 0, len(byID))
+	for _, e := range byID {
+		out = append(out, e)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, nil
+}
+
+// renderEnvironments declares an EnvironmentID constant per environment.
+func renderEnvironments(envs []environmentMeta) declSection {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// EnvironmentID is the ID of a GrowthBook environment.\n")
+	fmt.Fprintf(&b, "type EnvironmentID string\n")
+	if len(envs) == 0 {
+		return declSection{Body: b.Bytes()}
+	}
+
+	// Reserve the type name so an environment called "ID" cannot redeclare it.
+	nameCounts := map[string]int{"EnvironmentID": 1}
+	fmt.Fprintf(&b, "\nconst (\n")
+	for _, e := range envs {
+		name := "Environment" + toExportedIdentifier(e.ID)
+		nameCounts[name]++
+		if n := nameCounts[name]; n > 1 {
+			name = fmt.Sprintf("%s_%d", name, n)
+		}
+
+		fmt.Fprintf(&b, "\t// %s is the %q environment.\n", name, e.ID)
+		if desc := strings.TrimSpace(e.Description); desc != "" {
+			fmt.Fprintf(&b, "\t//\n")
+			for _, line := range strings.Split(desc, "\n") {
+				if line = strings.TrimSpace(line); line != "" {
+					fmt.Fprintf(&b, "\t// %s\n", line)
+				}
+			}
+		}
+		fmt.Fprintf(&b, "\t%s EnvironmentID = %q\n", name, e.ID)
+	}
+	fmt.Fprintf(&b, ")\n")
+	return declSection{Body: b.Bytes()}
+}
@@ -0,0 +1,60 @@
+package generator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eastnine90/gbgen/internal/config"
+	"github.com/eastnine90/gbgen/internal/growthbookapi"
+)
+
+func TestGeneratorGenerate_EnvironmentConstants(t *testing.T) {
+	envs := &growthbookapi.ListEnvironmentsResponse{}
+	envs.JSON200 = &struct {
+		Environments []growthbookapi.Environment `json:"environments"`
+	}{
+		Environments: []growthbookapi.Environment{
+			{Id: "production", Description: "Live traffic."},
+			{Id: "staging"},
+			{Id: "other-only", Projects: []string{"prj_other"}},
+		},
+	}
+	mock := &mockFeaturesAPI{
+		t: t,
+		featuresRespByOffset: map[int32]*growthbookapi.ListFeaturesResponse{
+			0: listFeaturesResponse([]growthbookapi.Feature{{
+				Id:           "checkout",
+				Environments: map[string]growthbookapi.FeatureEnvironment{"production": {Enabled: true}, "qa-eu": {Enabled: false}},
+				ValueType:    growthbookapi.FeatureValueTypeBoolean,
+			}}, nil),
+		},
+		environmentsResp: envs,
+	}
+	project := "prj_web"
+	cfg := config.Config{
+		GrowthBook: config.GrowthBookConfig{ProjectID: &project},
+		Generator:  config.GeneratorConfig{PackageName: "features", EmitEnvironments: true},
+	}
+	src, err := (&Generator{api: mock, config: cfg}).Generate(context.Background())
+	if err != nil {
+		t.Fatalf("Generate error: %v", err)
+	}
+	assertGofmtIdempotent(t, src)
+	out := string(src)
+
+	assertContains(t, out, "type EnvironmentID string")
+	assertContains(t, out, "\t// EnvironmentProduction is the \"production\" environment.\n\t//\n\t// Live traffic.\n\tEnvironmentProduction EnvironmentID = \"production\"\n")
+	assertContains(t, out, "EnvironmentStaging EnvironmentID = \"staging\"")
+	assertContains(t, out, "EnvironmentQaEu EnvironmentID = \"qa-eu\"")
+	assertNotContains(t, out, "other-only")
+}
+
+func TestRenderEnvironments_ReservesTypeName(t *testing.T) {
+	src, err := renderFeatureKeysGo("features", nil, false, renderEnvironments([]environmentMeta{{ID: "ID"}, {ID: "id"}}))
+	if err != nil {
+		t.Fatalf("render error: %v", err)
+	}
+	assertUniqueDecls(t, src)
+	assertContains(t, string(src), "\tEnvironmentID_2 EnvironmentID = \"ID\"\n")
+	assertContains(t, string(src), "\tEnvironmentId EnvironmentID = \"id\"\n")
+}
@@ -0,0 +1,168 @@
+package generator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// APIError is returned when GrowthBook answers a request with an unexpected status code or body.
+type APIError struct {
+	// StatusCode is the HTTP status code (0 if no response was available).
+	StatusCode int
+	// URL is the request URL with credentials and client keys redacted.
+	URL string
+	// Message is the error message reported by the server, if any.
+	Message string
+	// Hint is a short suggestion for fixing common configuration mistakes.
+	Hint string
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	b.WriteString("growthbook api: ")
+	if e.StatusCode != 0 {
+		fmt.Fprintf(&b, "%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	} else {
+		b.WriteString("no response")
+	}
+	if e.URL != "" {
+		fmt.Fprintf(&b, " from %s", e.URL)
+	}
+	if e.Message != "" {
+		fmt.Fprintf(&b, ": %s", e.Message)
+	}
+	if e.Hint != "" {
+		fmt.Fprintf(&b, " (%s)", e.Hint)
+	}
+	return b.String()
+}
+
+// restAPIHint suggests a fix for common REST API (secret key) failures.
+func restAPIHint(statusCode int) string {
+	switch statusCode {
+	case http.StatusUnauthorized:
+		return "check growthbook.apiKey"
+	case http.StatusForbidden:
+		return "key lacks readonly permission"
+	case http.StatusNotFound:
+		return "apiBaseURL probably wrong"
+	default:
+		return ""
+	}
+}
+
+// sdkPayloadHint suggests a fix for common SDK payload (client key) failures.
+func sdkPayloadHint(statusCode int) string {
+	switch statusCode {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound:
+		return "check growthbook.clientKey and growthbook.apiBaseURL"
+	default:
+		return ""
+	}
+}
+
+// newAPIError builds an APIError from an HTTP response and its already-read body.
+// Any of the given secrets found in the URL are redacted.
+func newAPIError(resp *http.Response, body []byte, hint func(int) string, secrets ...string) *APIError {
+	e := &APIError{}
+	if resp == nil {
+		return e
+	}
+	e.StatusCode = resp.StatusCode
+	if resp.Request != nil && resp.Request.URL != nil {
+		e.URL = redactURL(resp.Request.URL, secrets...)
+	}
+	e.Message = serverErrorMessage(resp.Header.Get("Content-Type"), body)
+	if e.StatusCode == http.StatusOK && e.Message == "" {
+		e.Message = fmt.Sprintf("unexpected response (Content-Type %q)", resp.Header.Get("Content-Type"))
+		e.Hint = hint(http.StatusNotFound)
+		return e
+	}
+	e.Hint = hint(e.StatusCode)
+	return e
+}
+
+// serverErrorMessage extracts a human-readable error message from a GrowthBook error body.
+func serverErrorMessage(contentType string, body []byte) string {
+	body = []byte(strings.TrimSpace(string(body)))
+	if len(body) == 0 {
+		return ""
+	}
+
+	if strings.Contains(contentType, "json") || body[0] == '{' {
+		var payload struct {
+			Message string `json:"message"`
+			Error   string `json:"error"`
+		}
+		if err := json.Unmarshal(body, &payload); err == nil {
+			if payload.Message != "" {
+				return payload.Message
+			}
+			return payload.Error
+		}
+	}
+
+	if strings.HasPrefix(contentType, "text/plain") {
+		msg, _, _ := strings.Cut(string(body), "\n")
+		const maxLen = 200
+		if len(msg) > maxLen {
+			msg = msg[:maxLen] + "..."
+		}
+		return msg
+	}
+	return ""
+}
+
+// sensitiveQueryParams are query parameters whose values are always redacted.
+var sensitiveQueryParams = []string{"apiKey", "api_key", "clientKey", "key", "token", "access_token", "secret"}
+
+// redactURL renders u without user info, sensitive query values or any of the given secrets.
+func redactURL(u *url.URL, secrets ...string) string {
+	c := *u
+	if c.User != nil {
+		c.User = url.User("REDACTED")
+	}
+	if c.RawQuery != "" {
+		q := c.Query()
+		for _, p := range sensitiveQueryParams {
+			if q.Has(p) {
+				q.Set(p, "REDACTED")
+			}
+		}
+		c.RawQuery = q.Encode()
+	}
+
+	return redactSecrets(c.String(), secrets...)
+}
+
+// redactSecrets replaces every occurrence of the given secrets (raw or URL-escaped) in s.
+func redactSecrets(s string, secrets ...string) string {
+	for _, secret := range secrets {
+		if secret == "" {
+			continue
+		}
+		s = strings.ReplaceAll(s, secret, "REDACTED")
+		s = strings.ReplaceAll(s, url.PathEscape(secret), "REDACTED")
+		s = strings.ReplaceAll(s, url.QueryEscape(secret), "REDACTED")
+	}
+	return s
+}
+
+// redactTransportError redacts the request URL embedded in transport errors (*url.Error),
+// keeping the underlying error available to errors.Is / errors.As.
+func redactTransportError(err error, secrets ...string) error {
+	var ue *url.Error
+	if !errors.As(err, &ue) {
+		return err
+	}
+	u, perr := url.Parse(ue.URL)
+	redacted := redactSecrets(ue.URL, secrets...)
+	if perr == nil {
+		redacted = redactURL(u, secrets...)
+	}
+	return &url.Error{Op: ue.Op, URL: redacted, Err: ue.Err}
+}
@@ -0,0 +1,69 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/eastnine90/gbgen/internal/config"
+)
+
+func TestGeneratorGenerate_APIErrorStatuses(t *testing.T) {
+	tests := []struct {
+		status int
+		body   string
+		hint   string
+	}{
+		{status: http.StatusUnauthorized, body: `{"message":"Invalid API key"}`, hint: "check growthbook.apiKey"},
+		{status: http.StatusForbidden, body: `{"message":"Forbidden"}`, hint: "key lacks readonly permission"},
+		{status: http.StatusNotFound, body: `{"message":"Unknown route"}`, hint: "apiBaseURL probably wrong"},
+		{status: http.StatusInternalServerError, body: `{"message":"boom"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			g, err := NewGenerator(config.Config{
+				GrowthBook: config.GrowthBookConfig{
+					APIBaseURL: srv.URL,
+					APIKey:     "secret_supersecret",
+					ClientKey:  "sdk-private",
+				},
+				Generator: config.GeneratorConfig{PackageName: "features"},
+			})
+			if err != nil {
+				t.Fatalf("NewGenerator error: %v", err)
+			}
+
+			_, err = g.Generate(context.Background())
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("expected *APIError, got %T (%v)", err, err)
+			}
+			if apiErr.StatusCode != tt.status {
+				t.Fatalf("status = %d, want %d", apiErr.StatusCode, tt.status)
+			}
+			if apiErr.Hint != tt.hint {
+				t.Fatalf("hint = %q, want %q", apiErr.Hint, tt.hint)
+			}
+			if !strings.Contains(apiErr.Message, strings.Trim(tt.body[len(`{"message":"`):], `"}`)) {
+				t.Fatalf("message = %q, want body message from %s", apiErr.Message, tt.body)
+			}
+			if !strings.HasPrefix(apiErr.URL, srv.URL+"/api/v1/features") {
+				t.Fatalf("url = %q", apiErr.URL)
+			}
+			if strings.Contains(err.Error(), "sdk-private") || strings.Contains(err.Error(), "supersecret") {
+				t.Fatalf("error leaks a secret: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,268 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/eastnine90/gbgen/internal/growthbookapi"
+)
+
+// experimentRef is an experiment a feature's rules refer to: either a linked experiment (ExperimentID)
+// or an inline experiment rule (TrackingKey and the names of its variations).
+type experimentRef struct {
+	ExperimentID string
+	TrackingKey  string
+	Variations   []string
+}
+
+// featureExperimentRefs collects the experiments referenced by the published rules of all environments.
+func featureExperimentRefs(envs map[string]growthbookapi.FeatureEnvironment) []experimentRef {
+	seen := map[[2]string]struct{}{}
+	var out []experimentRef
+	add := func(ref experimentRef) {
+		key := [2]string{ref.ExperimentID, ref.TrackingKey}
+		if _, ok := seen[key]; ok {
+			return
+		}
+		seen[key] = struct{}{}
+		out = append(out, ref)
+	}
+
+	envIDs := make([]string, 0, len(envs))
+	for id := range envs {
+		envIDs = append(envIDs, id)
+	}
+	sort.Strings(envIDs)
+
+	for _, id := range envIDs {
+		for _, rule := range envs[id].Rules {
+			// Rule types gbgen does not know about are not an error here.
+			v, err := rule.ValueByDiscriminator()
+			if err != nil {
+				continue
+			}
+			switch r := v.(type) {
+			case growthbookapi.FeatureExperimentRefRule:
+				if r.ExperimentId != "" {
+					add(experimentRef{ExperimentID: r.ExperimentId})
+				}
+			case growthbookapi.FeatureExperimentRule:
+				if r.TrackingKey == nil || *r.TrackingKey == "" {
+					continue
+				}
+				ref := experimentRef{TrackingKey: *r.TrackingKey}
+				if r.Value != nil {
+					for _, variation := range *r.Value {
+						name := ""
+						if variation.Name != nil {
+							name = *variation.Name
+						}
+						ref.Variations = append(ref.Variations, name)
+					}
+				}
+				add(ref)
+			}
+		}
+	}
+	return out
+}
+
+type variationMeta struct {
+	ID   string
+	Name string
+}
+
+type experimentMeta struct {
+	// ID is empty for inline experiment rules.
+	ID          string
+	Name        string
+	TrackingKey string
+	Variations  []variationMeta
+	// Features are the generated identifiers of the linked features, ordered by feature ID.
+	Features []string
+}
+
+// fetchExperiments lists all experiments, indexed by ID.
+func (g *Generator) fetchExperiments(ctx context.Context) (map[string]growthbookapi.Experiment, error) {
+	pageSize := g.config.GrowthBook.PageSize
+	if pageSize <= 0 || pageSize > maxPageSize {
+		pageSize = defaultPageSize
+	}
+
+	out := map[string]growthbookapi.Experiment{}
+	for offset := 0; ; {
+		resp, err := g.api.ListExperimentsWithResponse(ctx, &growthbookapi.ListExperimentsParams{
+			Limit:  &pageSize,
+			Offset: &offset,
+		})
+		if err != nil {
+			return nil, redactTransportError(err, g.secrets()...)
+		}
+		if resp == nil {
+			return nil, fmt.Errorf("list experiments: empty response")
+		}
+		if resp.JSON200 == nil {
+			return nil, fmt.Errorf("list experiments: %w", newAPIError(resp.HTTPResponse, resp.Body, restAPIHint, g.secrets()...))
+		}
+
+		for _, e := range resp.JSON200.Experiments {
+			if e.Id != "" {
+				out[e.Id] = e
+			}
+		}
+
+		if !resp.JSON200.HasMore {
+			break
+		}
+		offset, err = nextPageOffset(offset, resp.JSON200.NextOffset, len(resp.JSON200.Experiments))
+		if err != nil {
+			return nil, fmt.Errorf("list experiments: %w", err)
+		}
+	}
+	return out, nil
+}
+
+// loadExperiments resolves the experiments referenced by the generated features. Experiments are only listed
+// when a feature links one; inline experiment rules carry everything that is generated.
+func (g *Generator) loadExperiments(ctx context.Context, features []featureMeta) ([]experimentMeta, error) {
+	byKey := map[string]*experimentMeta{}
+	var order []string
+	var linked []string
+	for _, f := range nameAndDedupe(features) {
+		for _, ref := range f.Experiments {
+			key := "id:" + ref.ExperimentID
+			if ref.ExperimentID == "" {
+				key = "tk:" + ref.TrackingKey
+			}
+			e, ok := byKey[key]
+			if !ok {
+				e = &experimentMeta{ID: ref.ExperimentID, TrackingKey: ref.TrackingKey}
+				for i, name := range ref.Variations {
+					e.Variations = append(e.Variations, variationMeta{ID: strconv.Itoa(i), Name: name})
+				}
+				byKey[key] = e
+				order = append(order, key)
+				if ref.ExperimentID != "" {
+					linked = append(linked, key)
+				}
+			}
+			e.Features = append(e.Features, f.Name)
+		}
+	}
+
+	if len(linked) > 0 {
+		experiments, err := g.fetchExperiments(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range linked {
+			e := byKey[key]
+			api, ok := experiments[e.ID]
+			if !ok {
+				g.warnf("generator.emitExperiments: experiment %q linked from %s was not found", e.ID, strings.Join(e.Features, ", "))
+				delete(byKey, key)
+				continue
+			}
+			e.Name = api.Name
+			e.TrackingKey = api.TrackingKey
+			for _, v := range api.Variations {
+				e.Variations = append(e.Variations, variationMeta{ID: v.VariationId, Name: v.Name})
+			}
+		}
+	}
+
+	out := make([]experimentMeta, 0, len(byKey))
+	for _, key := range order {
+		if e, ok := byKey[key]; ok {
+			out = append(out, *e)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].TrackingKey != out[j].TrackingKey {
+			return out[i].TrackingKey < out[j].TrackingKey
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out, nil
+}
+
+// renderExperiments declares, per experiment, its tracking key, the features it is linked to and one VariationID
+// constant per variation. Inline experiment rules have no variation IDs; their variations are identified by index.
+func renderExperiments(experiments []experimentMeta) declSection {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// ExperimentTrackingKey is the key an experiment reports exposures with.\n")
+	fmt.Fprintf(&b, "type ExperimentTrackingKey string\n\n")
+	fmt.Fprintf(&b, "// VariationID identifies a variation of an experiment.\n")
+	fmt.Fprintf(&b, "type VariationID string\n")
+
+	// Reserve the type names, then name the tracking keys, the linked features and the variations of all
+	// experiments in that order, so a variation called "Feature" or an experiment called "TrackingKey" cannot take
+	// the name of another constant.
+	nameCounts := map[string]int{"ExperimentTrackingKey": 1, "VariationID": 1}
+	uniqueName := func(name string) string {
+		nameCounts[name]++
+		if n := nameCounts[name]; n > 1 {
+			name = fmt.Sprintf("%s_%d", name, n)
+		}
+		return name
+	}
+	labels := make([]string, len(experiments))
+	names := make([]string, len(experiments))
+	for i, e := range experiments {
+		labels[i] = e.Name
+		if strings.TrimSpace(labels[i]) == "" {
+			labels[i] = e.TrackingKey
+		}
+		names[i] = uniqueName("Experiment" + toExportedIdentifier(labels[i]))
+	}
+	featureNames := make([][]string, len(experiments))
+	for i, e := range experiments {
+		for range e.Features {
+			featureNames[i] = append(featureNames[i], uniqueName(names[i]+"Feature"))
+		}
+	}
+	variationNames := make([][]string, len(experiments))
+	for i, e := range experiments {
+		for j, v := range e.Variations {
+			vlabel := v.Name
+			if strings.TrimSpace(vlabel) == "" {
+				vlabel = fmt.Sprintf("Variation%d", j)
+			}
+			variationNames[i] = append(variationNames[i], uniqueName(names[i]+toExportedIdentifier(vlabel)))
+		}
+	}
+
+	for i, e := range experiments {
+		label, name := labels[i], names[i]
+
+		fmt.Fprintf(&b, "\n")
+		if e.ID != "" {
+			fmt.Fprintf(&b, "// Experiment %q (%s).\n", label, e.ID)
+		} else {
+			fmt.Fprintf(&b, "// Experiment %q (inline experiment rule).\n", label)
+		}
+		fmt.Fprintf(&b, "const (\n")
+		fmt.Fprintf(&b, "\t// %s is the tracking key of the experiment.\n", name)
+		fmt.Fprintf(&b, "\t%s ExperimentTrackingKey = %q\n", name, e.TrackingKey)
+		for j, feature := range e.Features {
+			featureName := featureNames[i][j]
+			fmt.Fprintf(&b, "\t// %s is the feature the experiment is linked to.\n", featureName)
+			fmt.Fprintf(&b, "\t%s = %s\n", featureName, feature)
+		}
+		for j, v := range e.Variations {
+			variationName := variationNames[i][j]
+			if v.Name != "" {
+				fmt.Fprintf(&b, "\t// %s is variation %d (%q).\n", variationName, j, v.Name)
+			} else {
+				fmt.Fprintf(&b, "\t// %s is variation %d.\n", variationName, j)
+			}
+			fmt.Fprintf(&b, "\t%s VariationID = %q\n", variationName, v.ID)
+		}
+		fmt.Fprintf(&b, ")\n")
+	}
+	return declSection{Body: b.Bytes()}
+}
@@ -0,0 +1,99 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/eastnine90/gbgen/internal/config"
+	"github.com/eastnine90/gbgen/internal/growthbookapi"
+)
+
+func TestGeneratorGenerate_Experiments(t *testing.T) {
+	var features []growthbookapi.Feature
+	if err := json.Unmarshal([]byte(`[
+  {"id": "checkout-v2", "valueType": "boolean", "environments": {"production": {"enabled": true, "rules": [
+    {"type": "experiment-ref", "id": "fr_1", "experimentId": "exp_123", "variations": [{"variationId": "var_a", "value": "false"}, {"variationId": "var_b", "value": "true"}]}
+  ]}}},
+  {"id": "banner-color", "valueType": "string", "environments": {"production": {"enabled": true, "rules": [
+    {"type": "experiment", "id": "fr_2", "condition": "", "trackingKey": "banner-test", "value": [{"name": "Blue", "value": "blue", "weight": 0.5}, {"value": "red", "weight": 0.5}]}
+  ]}}},
+  {"id": "missing-exp", "valueType": "boolean", "environments": {"production": {"enabled": true, "rules": [
+    {"type": "experiment-ref", "id": "fr_3", "experimentId": "exp_gone", "variations": []}
+  ]}}}
+]`), &features); err != nil {
+		t.Fatalf("unmarshal features: %v", err)
+	}
+
+	experiments := &growthbookapi.ListExperimentsResponse{}
+	experiments.JSON200 = &struct {
+		Count       int                        `json:"count"`
+		Experiments []growthbookapi.Experiment `json:"experiments"`
+		HasMore     bool                       `json:"hasMore"`
+		Limit       int                        `json:"limit"`
+		NextOffset  *int                       `json:"nextOffset"`
+		Offset      int                        `json:"offset"`
+		Total       int                        `json:"total"`
+	}{}
+	if err := json.Unmarshal([]byte(`[
+  {"id": "exp_123", "name": "Checkout redesign", "trackingKey": "checkout-redesign", "variations": [
+    {"variationId": "var_a", "key": "0", "name": "Control"},
+    {"variationId": "var_b", "key": "1", "name": "New checkout"}
+  ]},
+  {"id": "exp_unlinked", "name": "Unlinked", "trackingKey": "unlinked", "variations": []}
+]`), &experiments.JSON200.Experiments); err != nil {
+		t.Fatalf("unmarshal experiments: %v", err)
+	}
+
+	for _, typed := range []bool{false, true} {
+		mock := &mockFeaturesAPI{
+			t:                    t,
+			featuresRespByOffset: map[int32]*growthbookapi.ListFeaturesResponse{0: listFeaturesResponse(features, nil)},
+			experimentsResp:      experiments,
+		}
+		var warnings bytes.Buffer
+		cfg := config.Config{Generator: config.GeneratorConfig{PackageName: "features", EmitTypedFeatures: typed, EmitExperiments: true}}
+		src, err := (&Generator{api: mock, config: cfg, warnOut: &warnings}).Generate(context.Background())
+		if err != nil {
+			t.Fatalf("Generate (typed=%v) error: %v", typed, err)
+		}
+		assertGofmtIdempotent(t, src)
+		out := string(src)
+
+		assertContains(t, out, "type ExperimentTrackingKey string")
+		assertContains(t, out, "type VariationID string")
+		assertContains(t, out, "// Experiment \"Checkout redesign\" (exp_123).\nconst (\n\t// ExperimentCheckoutRedesign is the tracking key of the experiment.\n\tExperimentCheckoutRedesign ExperimentTrackingKey = \"checkout-redesign\"\n")
+		assertContains(t, out, "ExperimentCheckoutRedesignFeature = FeatureCheckoutV2\n")
+		assertContains(t, out, "\t// ExperimentCheckoutRedesignControl is variation 0 (\"Control\").\n\tExperimentCheckoutRedesignControl VariationID = \"var_a\"\n")
+		assertContains(t, out, "ExperimentCheckoutRedesignNewCheckout VariationID = \"var_b\"")
+
+		assertContains(t, out, "// Experiment \"banner-test\" (inline experiment rule).")
+		assertContains(t, out, "ExperimentBannerTestFeature = FeatureBannerColor\n")
+		assertContains(t, out, "ExperimentBannerTestBlue VariationID = \"0\"")
+		assertContains(t, out, "\t// ExperimentBannerTestVariation1 is variation 1.\n\tExperimentBannerTestVariation1 VariationID = \"1\"\n")
+
+		assertNotContains(t, out, "Unlinked")
+		assertNotContains(t, out, "exp_gone")
+		assertContains(t, warnings.String(), `experiment "exp_gone" linked from FeatureMissingExp was not found`)
+	}
+}
+
+func TestRenderExperiments_ReservedNames(t *testing.T) {
+	src, err := renderFeatureKeysGo("features", nil, false, renderExperiments([]experimentMeta{
+		{ID: "exp_1", Name: "A", TrackingKey: "a", Variations: []variationMeta{{ID: "v0", Name: "B Feature"}}},
+		{ID: "exp_2", Name: "A B", TrackingKey: "a-b", Features: []string{"FeatureX"}},
+		{ID: "exp_3", Name: "Tracking Key", TrackingKey: "tk"},
+		{ID: "exp_4", Name: "C", TrackingKey: "c", Features: []string{"FeatureY"}, Variations: []variationMeta{{ID: "v1", Name: "Feature"}}},
+	}))
+	if err != nil {
+		t.Fatalf("render error: %v", err)
+	}
+	assertUniqueDecls(t, src)
+	out := string(src)
+	assertContains(t, out, "\tExperimentTrackingKey_2 ExperimentTrackingKey = \"tk\"\n")
+	assertContains(t, out, "\tExperimentABFeature = FeatureX\n")
+	assertContains(t, out, "\tExperimentABFeature_2 VariationID = \"v0\"\n")
+	assertContains(t, out, "\tExperimentCFeature = FeatureY\n")
+	assertContains(t, out, "\tExperimentCFeature_2 VariationID = \"v1\"\n")
+}
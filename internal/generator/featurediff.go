@@ -0,0 +1,369 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/eastnine90/gbgen/internal/growthbookapi"
+)
+
+// GeneratedFeature is a feature constant of the generated code.
+type GeneratedFeature struct {
+	ID         string `json:"id"`
+	Identifier string `json:"identifier"`
+	// ValueType is "" when it is not known: keys-only code only records it in the manifest (generator.emitManifest).
+	ValueType string `json:"valueType,omitempty"`
+	// Deprecated is the reason given after "Deprecated:" in the doc comment, or "".
+	Deprecated string `json:"deprecated,omitempty"`
+}
+
+// FeatureChange is a feature whose generated constant changed from Old to New.
+type FeatureChange struct {
+	ID         string `json:"id"`
+	Identifier string `json:"identifier"`
+	Old        string `json:"old,omitempty"`
+	New        string `json:"new,omitempty"`
+}
+
+// FeatureDiff lists how the feature constants of two generations differ. Features are ordered by ID.
+type FeatureDiff struct {
+	Added            []GeneratedFeature `json:"added"`
+	Removed          []GeneratedFeature `json:"removed"`
+	ValueTypeChanged []FeatureChange    `json:"valueTypeChanged"`
+	// Deprecated lists the features that became deprecated; New is the reason.
+	Deprecated []FeatureChange `json:"deprecated"`
+	// Undeprecated lists the features that are no longer deprecated; Old is the former reason.
+	Undeprecated []FeatureChange `json:"undeprecated"`
+	// Renamed lists the features whose identifier changed; Old and New are the identifiers.
+	Renamed []FeatureChange `json:"renamed"`
+	// ValueTypesUnknown counts the features whose value type changes cannot be detected, because the earlier
+	// generation does not record their value type (keys-only code without a manifest).
+	ValueTypesUnknown int `json:"valueTypesUnknown"`
+}
+
+// Features fetches and filters the features like GenerateFiles and returns the constants it would declare,
+// without rendering any code.
+func (g *Generator) Features(ctx context.Context) ([]GeneratedFeature, error) {
+	features, _, err := g.loadFeatures(ctx)
+	if err != nil {
+		return nil, err
+	}
+	named := nameAndDedupe(features)
+	out := make([]GeneratedFeature, 0, len(named))
+	for _, f := range named {
+		// Read back from the doc comment, as for the code on disk, so a description line starting with
+		// "Deprecated:" counts on both sides.
+		var doc bytes.Buffer
+		writeFeatureDoc(&doc, f)
+		out = append(out, GeneratedFeature{
+			ID:         f.ID,
+			Identifier: f.Name,
+			ValueType:  string(f.ValueType),
+			Deprecated: deprecatedReasonOf(strings.Split(doc.String(), "\n")),
+		})
+	}
+	return out, nil
+}
+
+// ReadGeneratedFeatures parses the feature constants of the generated files in dir: mainName and, with
+// generator.split, the split files next to it.
+func ReadGeneratedFeatures(dir, mainName string) ([]GeneratedFeature, error) {
+	names, err := generatedSplitFiles(dir, mainName)
+	if err != nil {
+		return nil, err
+	}
+	names = append([]string{mainName}, names...)
+
+	var out []GeneratedFeature
+	var manifests []*manifest
+	for _, name := range names {
+		src, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		features, m, err := parseGeneratedFile(src)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Join(dir, name), err)
+		}
+		out = append(out, features...)
+		manifests = append(manifests, m)
+	}
+	// The manifest of the main file also covers the features of the split files.
+	for _, m := range manifests {
+		applyManifest(out, m)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, nil
+}
+
+// ParseGeneratedFeatures returns the feature constants declared in generated Go source: FeatureKey constants of
+// keys-only code and types.*Feature constants of typed code. Value types of keys-only code are taken from the
+// embedded manifest, if any.
+func ParseGeneratedFeatures(src []byte) ([]GeneratedFeature, error) {
+	features, m, err := parseGeneratedFile(src)
+	if err != nil {
+		return nil, err
+	}
+	applyManifest(features, m)
+	return features, nil
+}
+
+// parseGeneratedFile returns the feature constants declared in generated Go source and its manifest, if any.
+func parseGeneratedFile(src []byte) ([]GeneratedFeature, *manifest, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), "", src, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var out []GeneratedFeature
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vs, ok := spec.(*ast.ValueSpec)
+			if !ok || len(vs.Names) != 1 || len(vs.Values) != 1 {
+				continue
+			}
+			id, valueType, ok := featureConstValue(vs)
+			if !ok {
+				continue
+			}
+			out = append(out, GeneratedFeature{
+				ID:         id,
+				Identifier: vs.Names[0].Name,
+				ValueType:  valueType,
+				Deprecated: deprecatedReason(vs.Doc),
+			})
+		}
+	}
+
+	m, err := parseManifest(file)
+	if err != nil {
+		return nil, nil, err
+	}
+	return out, m, nil
+}
+
+// applyManifest fills in the value types the code does not record from m.
+func applyManifest(features []GeneratedFeature, m *manifest) {
+	if m == nil {
+		return
+	}
+	valueTypes := make(map[string]string, len(m.Features))
+	for _, f := range m.Features {
+		valueTypes[f.ID] = f.ValueType
+	}
+	for i, f := range features {
+		if f.ValueType == "" {
+			features[i].ValueType = valueTypes[f.ID]
+		}
+	}
+}
+
+// featureConstValue returns the feature ID and value type of a generated feature constant:
+// `FeatureX FeatureKey = "id"` or `FeatureX = types.BooleanFeature("id")`.
+func featureConstValue(vs *ast.ValueSpec) (id, valueType string, ok bool) {
+	if t, isIdent := vs.Type.(*ast.Ident); isIdent {
+		if t.Name != "FeatureKey" {
+			return "", "", false
+		}
+		id, ok = stringLit(vs.Values[0])
+		return id, "", ok
+	}
+
+	call, isCall := vs.Values[0].(*ast.CallExpr)
+	if !isCall || len(call.Args) != 1 {
+		return "", "", false
+	}
+	sel, isSel := call.Fun.(*ast.SelectorExpr)
+	if !isSel {
+		return "", "", false
+	}
+	pkg, isIdent := sel.X.(*ast.Ident)
+	if !isIdent {
+		return "", "", false
+	}
+	valueType, ok = typedFeatureValueType(pkg.Name + "." + sel.Sel.Name)
+	if !ok {
+		return "", "", false
+	}
+	id, ok = stringLit(call.Args[0])
+	return id, valueType, ok
+}
+
+// typedFeatureValueType is the inverse of typedFeatureTypeExpr.
+func typedFeatureValueType(expr string) (string, bool) {
+	for _, vt := range []growthbookapi.FeatureValueType{
+		growthbookapi.FeatureValueTypeBoolean,
+		growthbookapi.FeatureValueTypeString,
+		growthbookapi.FeatureValueTypeNumber,
+		growthbookapi.FeatureValueTypeJson,
+	} {
+		if e, err := typedFeatureTypeExpr(vt); err == nil && e == expr {
+			return string(vt), true
+		}
+	}
+	return "", false
+}
+
+func stringLit(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	s, err := strconv.Unquote(lit.Value)
+	return s, err == nil
+}
+
+// deprecatedReason returns the text after "Deprecated:" in a doc comment, or "".
+func deprecatedReason(doc *ast.CommentGroup) string {
+	if doc == nil {
+		return ""
+	}
+	lines := make([]string, 0, len(doc.List))
+	for _, c := range doc.List {
+		lines = append(lines, c.Text)
+	}
+	return deprecatedReasonOf(lines)
+}
+
+// deprecatedReasonOf returns the text after "Deprecated:" in the doc comment lines ("// ..."), or "". Any line may
+// carry it: earlier gbgen releases wrote it directly under the description, without an empty comment line in
+// between. The last one wins, as gbgen writes its notice after the description.
+func deprecatedReasonOf(lines []string) string {
+	reason := ""
+	for _, line := range lines {
+		line = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "//"))
+		if r, ok := strings.CutPrefix(line, "Deprecated:"); ok {
+			reason = strings.TrimSpace(r)
+		}
+	}
+	return reason
+}
+
+// DiffFeatures compares the feature constants of an earlier generation (old) with those of a new one, matching
+// features by ID. Value types are only compared when both sides record them.
+func DiffFeatures(old, new []GeneratedFeature) FeatureDiff {
+	d := FeatureDiff{
+		Added:            []GeneratedFeature{},
+		Removed:          []GeneratedFeature{},
+		ValueTypeChanged: []FeatureChange{},
+		Deprecated:       []FeatureChange{},
+		Undeprecated:     []FeatureChange{},
+		Renamed:          []FeatureChange{},
+	}
+
+	oldByID := make(map[string]GeneratedFeature, len(old))
+	for _, f := range old {
+		oldByID[f.ID] = f
+	}
+	newByID := make(map[string]GeneratedFeature, len(new))
+	for _, f := range new {
+		newByID[f.ID] = f
+	}
+
+	for _, f := range new {
+		o, ok := oldByID[f.ID]
+		if !ok {
+			d.Added = append(d.Added, f)
+			continue
+		}
+		if o.ValueType == "" {
+			d.ValueTypesUnknown++
+		}
+		if o.ValueType != "" && f.ValueType != "" && o.ValueType != f.ValueType {
+			d.ValueTypeChanged = append(d.ValueTypeChanged, FeatureChange{ID: f.ID, Identifier: f.Identifier, Old: o.ValueType, New: f.ValueType})
+		}
+		switch {
+		case o.Deprecated == "" && f.Deprecated != "":
+			d.Deprecated = append(d.Deprecated, FeatureChange{ID: f.ID, Identifier: f.Identifier, New: f.Deprecated})
+		case o.Deprecated != "" && f.Deprecated == "":
+			d.Undeprecated = append(d.Undeprecated, FeatureChange{ID: f.ID, Identifier: f.Identifier, Old: o.Deprecated})
+		}
+		if o.Identifier != f.Identifier {
+			d.Renamed = append(d.Renamed, FeatureChange{ID: f.ID, Identifier: f.Identifier, Old: o.Identifier, New: f.Identifier})
+		}
+	}
+	for _, f := range old {
+		if _, ok := newByID[f.ID]; !ok {
+			d.Removed = append(d.Removed, f)
+		}
+	}
+
+	sort.Slice(d.Added, func(i, j int) bool { return d.Added[i].ID < d.Added[j].ID })
+	sort.Slice(d.Removed, func(i, j int) bool { return d.Removed[i].ID < d.Removed[j].ID })
+	for _, changes := range [][]FeatureChange{d.ValueTypeChanged, d.Deprecated, d.Undeprecated, d.Renamed} {
+		sort.Slice(changes, func(i, j int) bool { return changes[i].ID < changes[j].ID })
+	}
+	return d
+}
+
+// Empty reports whether the generations declare the same feature constants.
+func (d FeatureDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.ValueTypeChanged) == 0 &&
+		len(d.Deprecated) == 0 && len(d.Undeprecated) == 0 && len(d.Renamed) == 0
+}
+
+// WriteText writes a human-readable report of d, one section per kind of change.
+func (d FeatureDiff) WriteText(w io.Writer) error {
+	var b strings.Builder
+	if d.Empty() {
+		b.WriteString("No feature changes.\n")
+	}
+	section := func(title string, n int) {
+		if n > 0 {
+			fmt.Fprintf(&b, "%s (%d):\n", title, n)
+		}
+	}
+
+	section("Added", len(d.Added))
+	for _, f := range d.Added {
+		fmt.Fprintf(&b, "  + %s %s\n", f.Identifier, featureLabel(f.ID, f.ValueType))
+	}
+	section("Removed", len(d.Removed))
+	for _, f := range d.Removed {
+		fmt.Fprintf(&b, "  - %s %s\n", f.Identifier, featureLabel(f.ID, f.ValueType))
+	}
+	section("Value type changed", len(d.ValueTypeChanged))
+	for _, c := range d.ValueTypeChanged {
+		fmt.Fprintf(&b, "  ~ %s (%q): %s -> %s\n", c.Identifier, c.ID, c.Old, c.New)
+	}
+	section("Deprecated", len(d.Deprecated))
+	for _, c := range d.Deprecated {
+		fmt.Fprintf(&b, "  ! %s (%q): %s\n", c.Identifier, c.ID, c.New)
+	}
+	section("No longer deprecated", len(d.Undeprecated))
+	for _, c := range d.Undeprecated {
+		fmt.Fprintf(&b, "  * %s (%q), was: %s\n", c.Identifier, c.ID, c.Old)
+	}
+	section("Renamed", len(d.Renamed))
+	for _, c := range d.Renamed {
+		fmt.Fprintf(&b, "  > %s -> %s (%q)\n", c.Old, c.New, c.ID)
+	}
+	if d.ValueTypesUnknown > 0 {
+		fmt.Fprintf(&b, "Value type changes cannot be detected for %d feature(s): the generated code does not record "+
+			"their value types (set generator.emitTypedFeatures or generator.emitManifest).\n", d.ValueTypesUnknown)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func featureLabel(id, valueType string) string {
+	if valueType == "" {
+		return fmt.Sprintf("(%q)", id)
+	}
+	return fmt.Sprintf("(%q, %s)", id, valueType)
+}
@@ -0,0 +1,193 @@
+package generator
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/eastnine90/gbgen/internal/config"
+	"github.com/eastnine90/gbgen/internal/growthbookapi"
+)
+
+func TestParseGeneratedFeatures_RoundTrip(t *testing.T) {
+	features := []growthbookapi.Feature{
+		{Id: "checkout-v2", ValueType: growthbookapi.FeatureValueTypeBoolean, Description: "New checkout.\n\nDeprecated: not really",
+			Environments: map[string]growthbookapi.FeatureEnvironment{"production": {Enabled: true}}},
+		{Id: "old-banner", ValueType: growthbookapi.FeatureValueTypeString},
+		{Id: "limits", ValueType: growthbookapi.FeatureValueTypeJson, Archived: true,
+			Environments: map[string]growthbookapi.FeatureEnvironment{"production": {Enabled: true}}},
+	}
+
+	for _, typed := range []bool{false, true} {
+		newGenerator := func() *Generator {
+			mock := &mockFeaturesAPI{
+				t:                    t,
+				featuresRespByOffset: map[int32]*growthbookapi.ListFeaturesResponse{0: listFeaturesResponse(features, nil)},
+			}
+			cfg := config.Config{Generator: config.GeneratorConfig{
+				PackageName:       "features",
+				EmitTypedFeatures: typed,
+				EmitFeatureList:   true,
+				ArchivedPolicy:    config.ArchivedDeprecate,
+			}}
+			return &Generator{api: mock, config: cfg}
+		}
+
+		src, err := newGenerator().Generate(context.Background())
+		if err != nil {
+			t.Fatalf("Generate (typed=%v) error: %v", typed, err)
+		}
+		parsed, err := ParseGeneratedFeatures(src)
+		if err != nil {
+			t.Fatalf("ParseGeneratedFeatures (typed=%v) error: %v", typed, err)
+		}
+		want, err := newGenerator().Features(context.Background())
+		if err != nil {
+			t.Fatalf("Features (typed=%v) error: %v", typed, err)
+		}
+		if !typed {
+			// Keys-only code does not record value types.
+			for i := range want {
+				want[i].ValueType = ""
+			}
+		}
+		if !reflect.DeepEqual(parsed, want) {
+			t.Fatalf("typed=%v:\nparsed   %+v\nFeatures %+v", typed, parsed, want)
+		}
+		if d := DiffFeatures(parsed, want); !d.Empty() {
+			t.Fatalf("typed=%v: DiffFeatures of the same features = %+v", typed, d)
+		}
+	}
+}
+
+func TestParseGeneratedFeatures_ReleasedLayout(t *testing.T) {
+	// Earlier releases put "Deprecated:" directly under the description, without an empty comment line.
+	src := []byte(`// Code generated by gbgen v0.1.0. DO NOT EDIT.
+
+package features
+
+type FeatureKey string
+
+func (f FeatureKey) Key() string { return string(f) }
+
+const (
+	// Checkout redesign flag
+	// Deprecated: no active environments
+	FeatureCheckoutRedesign FeatureKey = "checkout-redesign"
+	// Deprecated: no active environments
+	FeatureOldBanner FeatureKey = "old-banner"
+	// Live flag
+	FeatureLive FeatureKey = "live"
+)
+`)
+	got, err := ParseGeneratedFeatures(src)
+	if err != nil {
+		t.Fatalf("ParseGeneratedFeatures error: %v", err)
+	}
+	want := []GeneratedFeature{
+		{ID: "checkout-redesign", Identifier: "FeatureCheckoutRedesign", Deprecated: "no active environments"},
+		{ID: "old-banner", Identifier: "FeatureOldBanner", Deprecated: "no active environments"},
+		{ID: "live", Identifier: "FeatureLive"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ParseGeneratedFeatures = %+v, want %+v", got, want)
+	}
+
+	current := []GeneratedFeature{
+		{ID: "checkout-redesign", Identifier: "FeatureCheckoutRedesign", ValueType: "boolean", Deprecated: "no active environments"},
+		{ID: "old-banner", Identifier: "FeatureOldBanner", ValueType: "string", Deprecated: "no active environments"},
+		{ID: "live", Identifier: "FeatureLive", ValueType: "boolean"},
+	}
+	if d := DiffFeatures(got, current); len(d.Deprecated) != 0 || len(d.Undeprecated) != 0 {
+		t.Fatalf("DiffFeatures against the released layout reports deprecation changes: %+v", d)
+	}
+}
+
+func TestDiffFeatures(t *testing.T) {
+	old := []GeneratedFeature{
+		{ID: "checkout", Identifier: "FeatureCheckout", ValueType: "boolean"},
+		{ID: "limit", Identifier: "FeatureLimit", ValueType: "string"},
+		{ID: "banner", Identifier: "FeatureBanner", Deprecated: "no active environments"},
+		{ID: "gone", Identifier: "FeatureGone", ValueType: "number"},
+		{ID: "search", Identifier: "FeatureSearch"},
+	}
+	current := []GeneratedFeature{
+		{ID: "checkout", Identifier: "FeatureCheckout", ValueType: "boolean", Deprecated: "archived in GrowthBook"},
+		{ID: "limit", Identifier: "FeatureLimit", ValueType: "number"},
+		{ID: "banner", Identifier: "FeatureBanner", ValueType: "string"},
+		{ID: "new-flag", Identifier: "FeatureNewFlag", ValueType: "json"},
+		{ID: "search", Identifier: "FeatureSearch_2", ValueType: "string"},
+	}
+
+	d := DiffFeatures(old, current)
+	if d.Empty() {
+		t.Fatal("DiffFeatures reported no changes")
+	}
+	got, err := json.Marshal(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"added":[{"id":"new-flag","identifier":"FeatureNewFlag","valueType":"json"}],` +
+		`"removed":[{"id":"gone","identifier":"FeatureGone","valueType":"number"}],` +
+		`"valueTypeChanged":[{"id":"limit","identifier":"FeatureLimit","old":"string","new":"number"}],` +
+		`"deprecated":[{"id":"checkout","identifier":"FeatureCheckout","new":"archived in GrowthBook"}],` +
+		`"undeprecated":[{"id":"banner","identifier":"FeatureBanner","old":"no active environments"}],` +
+		`"renamed":[{"id":"search","identifier":"FeatureSearch_2","old":"FeatureSearch","new":"FeatureSearch_2"}],` +
+		`"valueTypesUnknown":2}`
+	if string(got) != want {
+		t.Fatalf("DiffFeatures JSON:\n%s\nwant:\n%s", got, want)
+	}
+
+	var text strings.Builder
+	if err := d.WriteText(&text); err != nil {
+		t.Fatal(err)
+	}
+	out := text.String()
+	assertContains(t, out, "Added (1):\n  + FeatureNewFlag (\"new-flag\", json)\n")
+	assertContains(t, out, "Removed (1):\n  - FeatureGone (\"gone\", number)\n")
+	assertContains(t, out, "  ~ FeatureLimit (\"limit\"): string -> number\n")
+	assertContains(t, out, "  ! FeatureCheckout (\"checkout\"): archived in GrowthBook\n")
+	assertContains(t, out, "No longer deprecated (1):\n")
+	assertContains(t, out, "  > FeatureSearch -> FeatureSearch_2 (\"search\")\n")
+	assertContains(t, out, "Value type changes cannot be detected for 2 feature(s)")
+
+	empty, err := json.Marshal(DiffFeatures(old, old))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(empty) != `{"added":[],"removed":[],"valueTypeChanged":[],"deprecated":[],"undeprecated":[],"renamed":[],"valueTypesUnknown":2}` {
+		t.Fatalf("DiffFeatures of the same features = %s", empty)
+	}
+}
+
+func TestReadGeneratedFeatures_SplitFiles(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, src string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("features.gen.go", "// Code generated by gbgen v1. DO NOT EDIT.\n\npackage features\n\ntype FeatureKey string\n\nconst (\n\tFeatureB FeatureKey = \"b\"\n)\n")
+	write("features_web.gen.go", "// Code generated by gbgen v1. DO NOT EDIT.\n\npackage features\n\nconst (\n\t// Deprecated: archived in GrowthBook\n\tFeatureA FeatureKey = \"a\"\n)\n")
+	write("features_mine.gen.go", "package features\n\nconst FeatureC FeatureKey = \"c\"\n")
+
+	got, err := ReadGeneratedFeatures(dir, "features.gen.go")
+	if err != nil {
+		t.Fatalf("ReadGeneratedFeatures error: %v", err)
+	}
+	want := []GeneratedFeature{
+		{ID: "a", Identifier: "FeatureA", Deprecated: "archived in GrowthBook"},
+		{ID: "b", Identifier: "FeatureB"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ReadGeneratedFeatures = %+v, want %+v", got, want)
+	}
+
+	if _, err := ReadGeneratedFeatures(filepath.Join(dir, "missing"), "features.gen.go"); !os.IsNotExist(err) {
+		t.Fatalf("ReadGeneratedFeatures of a missing dir: err = %v, want not exist", err)
+	}
+}
@@ -0,0 +1,239 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/eastnine90/gbgen/internal/config"
+)
+
+// filterFeatures applies the generator's feature filters. It runs before naming and dedupe,
+// so identifiers only depend on the features that end up in the output.
+func (g *Generator) filterFeatures(features []featureMeta) ([]featureMeta, error) {
+	features = g.applyArchivedPolicy(features)
+	features = g.applyEnvironments(features)
+	features = g.filterByTags(features)
+	features, err := g.filterByIDPatterns(features)
+	if err != nil {
+		return nil, err
+	}
+	return g.applyStripPrefix(features)
+}
+
+// applyArchivedPolicy drops archived features ("skip"), keeps them marked for deprecation ("deprecate", default)
+// or treats them like any other feature ("include").
+func (g *Generator) applyArchivedPolicy(features []featureMeta) []featureMeta {
+	policy := g.config.Generator.ArchivedPolicy
+	out := features[:0:0]
+	for _, f := range features {
+		if f.Archived {
+			switch policy {
+			case config.ArchivedSkip:
+				continue
+			case config.ArchivedInclude:
+				f.Archived = false
+			}
+		}
+		out = append(out, f)
+	}
+	return out
+}
+
+// applyEnvironments decides which features count as inactive and applies generator.inactivePolicy.
+//
+// When generator.environments is set, a feature is inactive if it is enabled in none of the listed environments,
+// and the environments it is enabled in are recorded for its doc comment. Otherwise any enabled environment counts.
+func (g *Generator) applyEnvironments(features []featureMeta) []featureMeta {
+	envs := g.config.Generator.Environments
+	if len(envs) > 0 {
+		known := map[string]bool{}
+		anyInfo := false
+		for i := range features {
+			f := &features[i]
+			if f.Environments == nil {
+				continue
+			}
+			anyInfo = true
+			f.CheckedEnvs = envs
+			f.EnabledIn = nil
+			for _, env := range envs {
+				enabled, ok := f.Environments[env]
+				if ok {
+					known[env] = true
+				}
+				if enabled {
+					f.EnabledIn = append(f.EnabledIn, env)
+				}
+			}
+			f.NoActiveEnvs = len(f.EnabledIn) == 0
+		}
+
+		switch {
+		case !anyInfo && len(features) > 0:
+			g.warnf("generator.environments: the %s source has no environment information", g.config.Generator.SourceMode())
+		case anyInfo:
+			for _, env := range envs {
+				if !known[env] {
+					g.warnf("generator.environments: no feature has environment %q", env)
+				}
+			}
+		}
+	}
+
+	out := features[:0:0]
+	for _, f := range features {
+		if f.NoActiveEnvs {
+			switch g.config.Generator.InactivePolicy {
+			case config.InactiveSkip:
+				continue
+			case config.InactiveInclude:
+				f.NoActiveEnvs = false
+			}
+		}
+		out = append(out, f)
+	}
+	return out
+}
+
+// filterByTags keeps features carrying any of generator.includeTags (when set) and drops features
+// carrying any of generator.excludeTags. A configured tag that no feature carries is reported as a warning.
+func (g *Generator) filterByTags(features []featureMeta) []featureMeta {
+	include := g.config.Generator.IncludeTags
+	exclude := g.config.Generator.ExcludeTags
+	if len(include) == 0 && len(exclude) == 0 {
+		return features
+	}
+
+	seen := map[string]bool{}
+	out := features[:0:0]
+	for _, f := range features {
+		for _, tag := range f.Tags {
+			seen[tag] = true
+		}
+		if len(include) > 0 && !hasAnyTag(f.Tags, include) {
+			continue
+		}
+		if hasAnyTag(f.Tags, exclude) {
+			continue
+		}
+		out = append(out, f)
+	}
+
+	for _, tag := range include {
+		if !seen[tag] {
+			g.warnf("generator.includeTags: no feature is tagged %q", tag)
+		}
+	}
+	for _, tag := range exclude {
+		if !seen[tag] {
+			g.warnf("generator.excludeTags: no feature is tagged %q", tag)
+		}
+	}
+	if len(include) > 0 && len(out) == 0 {
+		g.warnf("generator.includeTags: no features left to generate")
+	}
+	return out
+}
+
+// filterByIDPatterns keeps features whose ID matches any of generator.includePatterns (when set) and drops
+// features whose ID matches any of generator.excludePatterns. Patterns are unanchored regular expressions.
+func (g *Generator) filterByIDPatterns(features []featureMeta) ([]featureMeta, error) {
+	include, err := compilePatterns("generator.includePatterns", g.config.Generator.IncludePatterns)
+	if err != nil {
+		return nil, err
+	}
+	exclude, err := compilePatterns("generator.excludePatterns", g.config.Generator.ExcludePatterns)
+	if err != nil {
+		return nil, err
+	}
+	if len(include) == 0 && len(exclude) == 0 {
+		return features, nil
+	}
+
+	matched := make([]bool, len(include))
+	out := features[:0:0]
+	for _, f := range features {
+		keep := len(include) == 0
+		for i, re := range include {
+			if re.MatchString(f.ID) {
+				matched[i] = true
+				keep = true
+			}
+		}
+		if !keep || matchesAny(exclude, f.ID) {
+			continue
+		}
+		out = append(out, f)
+	}
+
+	for i, re := range include {
+		if !matched[i] {
+			g.warnf("generator.includePatterns: no feature ID matches %q", re.String())
+		}
+	}
+	return out, nil
+}
+
+func compilePatterns(key string, patterns []string) ([]*regexp.Regexp, error) {
+	out := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		out = append(out, re)
+	}
+	return out, nil
+}
+
+func matchesAny(res []*regexp.Regexp, s string) bool {
+	for _, re := range res {
+		if re.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyStripPrefix derives identifiers from feature IDs without generator.stripPrefix.
+// It fails if stripping makes two features share an identifier that they would not share otherwise,
+// instead of silently numbering them.
+func (g *Generator) applyStripPrefix(features []featureMeta) ([]featureMeta, error) {
+	prefix := g.config.Generator.StripPrefix
+	if prefix == "" {
+		return features, nil
+	}
+
+	owners := map[string]featureMeta{}
+	for i := range features {
+		f := &features[i]
+		if stripped := strings.TrimPrefix(f.ID, prefix); stripped != f.ID && stripped != "" {
+			f.NameID = stripped
+		}
+		name := f.baseName()
+		if other, ok := owners[name]; ok && toExportedIdentifier(other.ID) != toExportedIdentifier(f.ID) {
+			return nil, fmt.Errorf("generator.stripPrefix %q: features %q and %q would both be named %s", prefix, other.ID, f.ID, name)
+		}
+		owners[name] = *f
+	}
+	return features, nil
+}
+
+func hasAnyTag(tags, want []string) bool {
+	for _, tag := range tags {
+		if slices.Contains(want, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// warnf reports a non-fatal problem. Warnings are dropped when the Generator has no warning output.
+func (g *Generator) warnf(format string, args ...any) {
+	if g.warnOut == nil {
+		return
+	}
+	fmt.Fprintf(g.warnOut, "gbgen: warning: "+format+"\n", args...)
+}
@@ -0,0 +1,188 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/eastnine90/gbgen/internal/config"
+	"github.com/eastnine90/gbgen/internal/growthbookapi"
+)
+
+func TestGeneratorGenerate_TagFilters(t *testing.T) {
+	feature := func(id string, tags ...string) growthbookapi.Feature {
+		return growthbookapi.Feature{
+			Id:           id,
+			Environments: map[string]growthbookapi.FeatureEnvironment{"production": {Enabled: true}},
+			ValueType:    growthbookapi.FeatureValueTypeBoolean,
+			Tags:         tags,
+		}
+	}
+	mock := &mockFeaturesAPI{
+		t: t,
+		featuresRespByOffset: map[int32]*growthbookapi.ListFeaturesResponse{
+			0: listFeaturesResponse([]growthbookapi.Feature{
+				feature("new-checkout", "team:growth"),
+				feature("new_checkout", "team:payments"),
+				feature("refunds-v2", "team:payments", "sunset"),
+				feature("wallet", "team:payments"),
+			}, nil),
+		},
+	}
+	cfg := config.Config{Generator: config.GeneratorConfig{
+		PackageName: "features",
+		IncludeTags: []string{"team:payments", "team:typo"},
+		ExcludeTags: []string{"sunset", "internal-only"},
+	}}
+
+	var warnings bytes.Buffer
+	src, err := (&Generator{api: mock, config: cfg, warnOut: &warnings}).Generate(context.Background())
+	if err != nil {
+		t.Fatalf("Generate error: %v", err)
+	}
+	out := string(src)
+
+	// The filtered-out "new-checkout" must not push "new_checkout" to a _2 suffix.
+	assertContains(t, out, "FeatureNewCheckout FeatureKey = \"new_checkout\"")
+	assertContains(t, out, "= \"wallet\"")
+	assertNotContains(t, out, "\"new-checkout\"")
+	assertNotContains(t, out, "refunds-v2")
+
+	assertContains(t, warnings.String(), "gbgen: warning: generator.includeTags: no feature is tagged \"team:typo\"\n")
+	assertContains(t, warnings.String(), "gbgen: warning: generator.excludeTags: no feature is tagged \"internal-only\"\n")
+	assertNotContains(t, warnings.String(), "team:payments")
+}
+
+func TestGeneratorGenerate_ArchivedPolicy(t *testing.T) {
+	features := []growthbookapi.Feature{
+		{
+			Id:           "old-banner",
+			Description:  "Spring sale banner",
+			Archived:     true,
+			Environments: map[string]growthbookapi.FeatureEnvironment{"production": {Enabled: true}},
+			ValueType:    growthbookapi.FeatureValueTypeBoolean,
+		},
+		{
+			Id:           "live-flag",
+			Environments: map[string]growthbookapi.FeatureEnvironment{"production": {Enabled: true}},
+			ValueType:    growthbookapi.FeatureValueTypeBoolean,
+		},
+	}
+	generate := func(policy string) string {
+		t.Helper()
+		mock := &mockFeaturesAPI{
+			t:                    t,
+			featuresRespByOffset: map[int32]*growthbookapi.ListFeaturesResponse{0: listFeaturesResponse(features, nil)},
+		}
+		cfg := config.Config{Generator: config.GeneratorConfig{PackageName: "features", EmitTypedFeatures: true, ArchivedPolicy: policy}}
+		src, err := (&Generator{api: mock, config: cfg}).Generate(context.Background())
+		if err != nil {
+			t.Fatalf("Generate (%s) error: %v", policy, err)
+		}
+		assertGofmtIdempotent(t, src)
+		return string(src)
+	}
+
+	out := generate(config.ArchivedDeprecate)
+	assertContains(t, out, "\t// Spring sale banner\n\t//\n\t// Deprecated: archived in GrowthBook\n\tFeatureOldBanner ")
+	assertContains(t, out, "FeatureLiveFlag ")
+
+	out = generate(config.ArchivedSkip)
+	assertNotContains(t, out, "old-banner")
+	assertContains(t, out, "FeatureLiveFlag ")
+
+	out = generate(config.ArchivedInclude)
+	assertContains(t, out, "\t// Spring sale banner\n\tFeatureOldBanner ")
+	assertNotContains(t, out, "Deprecated")
+}
+
+func TestGeneratorGenerate_Environments(t *testing.T) {
+	feature := func(id string, envs map[string]bool) growthbookapi.Feature {
+		f := growthbookapi.Feature{Id: id, ValueType: growthbookapi.FeatureValueTypeBoolean, Environments: map[string]growthbookapi.FeatureEnvironment{}}
+		for env, enabled := range envs {
+			f.Environments[env] = growthbookapi.FeatureEnvironment{Enabled: enabled}
+		}
+		return f
+	}
+	features := []growthbookapi.Feature{
+		feature("everywhere", map[string]bool{"production": true, "staging": true, "dev": true}),
+		feature("staging-only", map[string]bool{"production": false, "staging": true}),
+		feature("dev-only", map[string]bool{"production": false, "staging": false, "dev": true}),
+	}
+	generate := func(policy string) (string, string) {
+		t.Helper()
+		mock := &mockFeaturesAPI{
+			t:                    t,
+			featuresRespByOffset: map[int32]*growthbookapi.ListFeaturesResponse{0: listFeaturesResponse(features, nil)},
+		}
+		cfg := config.Config{Generator: config.GeneratorConfig{
+			PackageName:    "features",
+			Environments:   []string{"production", "staging", "qa"},
+			InactivePolicy: policy,
+		}}
+		var warnings bytes.Buffer
+		src, err := (&Generator{api: mock, config: cfg, warnOut: &warnings}).Generate(context.Background())
+		if err != nil {
+			t.Fatalf("Generate (%s) error: %v", policy, err)
+		}
+		assertGofmtIdempotent(t, src)
+		return string(src), warnings.String()
+	}
+
+	out, warnings := generate(config.InactiveDeprecate)
+	assertContains(t, out, "\t// Enabled in: production, staging\n\tFeatureEverywhere ")
+	assertContains(t, out, "\t// Enabled in: staging\n\tFeatureStagingOnly ")
+	assertContains(t, out, "\t// Deprecated: not enabled in production, staging, qa\n\tFeatureDevOnly ")
+	assertContains(t, warnings, "generator.environments: no feature has environment \"qa\"")
+
+	out, _ = generate(config.InactiveSkip)
+	assertNotContains(t, out, "dev-only")
+	assertContains(t, out, "FeatureStagingOnly ")
+
+	out, _ = generate(config.InactiveInclude)
+	assertContains(t, out, "\tFeatureDevOnly ")
+	assertNotContains(t, out, "Deprecated")
+}
+
+func TestGeneratorGenerate_IDPatternsAndStripPrefix(t *testing.T) {
+	feature := func(id string) growthbookapi.Feature {
+		return growthbookapi.Feature{
+			Id:           id,
+			Environments: map[string]growthbookapi.FeatureEnvironment{"production": {Enabled: true}},
+			ValueType:    growthbookapi.FeatureValueTypeBoolean,
+		}
+	}
+	generate := func(gen config.GeneratorConfig, ids ...string) (string, error) {
+		t.Helper()
+		var features []growthbookapi.Feature
+		for _, id := range ids {
+			features = append(features, feature(id))
+		}
+		mock := &mockFeaturesAPI{
+			t:                    t,
+			featuresRespByOffset: map[int32]*growthbookapi.ListFeaturesResponse{0: listFeaturesResponse(features, nil)},
+		}
+		gen.PackageName = "payments"
+		src, err := (&Generator{api: mock, config: config.Config{Generator: gen}}).Generate(context.Background())
+		return string(src), err
+	}
+
+	out, err := generate(config.GeneratorConfig{
+		IncludePatterns: []string{`^payments\.`},
+		ExcludePatterns: []string{`\.legacy-`},
+		StripPrefix:     "payments.",
+	}, "payments.checkout-redesign", "payments.legacy-refunds", "growth.checkout-redesign", "payments.wallet")
+	if err != nil {
+		t.Fatalf("Generate error: %v", err)
+	}
+	assertContains(t, out, "FeatureCheckoutRedesign FeatureKey = \"payments.checkout-redesign\"")
+	assertContains(t, out, "= \"payments.wallet\"")
+	assertNotContains(t, out, "legacy-refunds")
+	assertNotContains(t, out, "growth.checkout-redesign")
+
+	_, err = generate(config.GeneratorConfig{StripPrefix: "payments."}, "payments.wallet", "wallet")
+	if err == nil || !strings.Contains(err.Error(), `features "payments.wallet" and "wallet" would both be named FeatureWallet`) {
+		t.Fatalf("expected stripPrefix collision error, got %v", err)
+	}
+}
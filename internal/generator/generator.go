@@ -2,51 +2,189 @@ package generator
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"net/http"
-	"strings"
+	"os"
 
 	"github.com/eastnine90/gbgen/internal/config"
 	"github.com/eastnine90/gbgen/internal/growthbookapi"
+	"github.com/eastnine90/gbgen/internal/httpcache"
 )
 
 type Generator struct {
-	api    growthbookapi.ClientWithResponsesInterface
-	config config.Config
+	api        growthbookapi.ClientWithResponsesInterface
+	httpClient growthbookapi.HttpRequestDoer
+	config     config.Config
+
+	// warnOut receives non-fatal warnings (os.Stderr when built by NewGenerator).
+	warnOut io.Writer
 }
 
 func NewGenerator(cfg config.Config) (*Generator, error) {
-	base := strings.TrimRight(cfg.GrowthBook.APIBaseURL, "/")
-	if !strings.HasSuffix(base, "/api/v1") {
-		base = base + "/api/v1"
+	base := restBaseURL(cfg.GrowthBook)
+
+	baseClient, err := newHTTPClient(cfg.GrowthBook.HTTP)
+	if err != nil {
+		return nil, err
+	}
+	var httpClient growthbookapi.HttpRequestDoer = newRetryDoer(baseClient, cfg.GrowthBook.Retry, cfg.GrowthBook.HTTP)
+	if cfg.Cache.Enabled {
+		dir, err := httpcache.ResolveDir(cfg.Cache.Dir)
+		if err != nil {
+			return nil, fmt.Errorf("resolve cache dir: %w", err)
+		}
+		httpClient = httpcache.New(httpClient, dir, cfg.Cache.TTL.Std(), cfg.GrowthBook.HTTP.Headers)
 	}
 
-	api, err := growthbookapi.NewClientWithResponses(base, growthbookapi.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
-		req.Header.Set("Authorization", "Bearer "+cfg.GrowthBook.APIKey)
-		return nil
-	}))
+	api, err := growthbookapi.NewClientWithResponses(base,
+		growthbookapi.WithHTTPClient(httpClient),
+		growthbookapi.WithRequestEditorFn(authEditor(cfg.GrowthBook)),
+	)
 
 	if err != nil {
 		return nil, err
 	}
 
 	return &Generator{
-		api:    api,
-		config: cfg,
+		api:        api,
+		httpClient: httpClient,
+		config:     cfg,
+		warnOut:    os.Stderr,
 	}, nil
 }
 
+// authEditor sets the Authorization header according to growthbook.auth.method.
+// The key must already be resolved into APIKey (see config.GrowthBookConfig.ResolveAPIKey).
+func authEditor(gb config.GrowthBookConfig) growthbookapi.RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		switch gb.Auth.Method {
+		case config.AuthBasic:
+			req.SetBasicAuth(gb.APIKey, "")
+		default:
+			req.Header.Set("Authorization", "Bearer "+gb.APIKey)
+		}
+		return nil
+	}
+}
+
+// Generate renders the generated package as a single file. It fails when generator.split is set; use GenerateFiles.
 func (g *Generator) Generate(ctx context.Context) ([]byte, error) {
-	features, err := g.fetchAllFeatureMeta(ctx)
+	if g.config.Generator.Split != "" {
+		return nil, fmt.Errorf("generator.split is %q: the output has several files", g.config.Generator.Split)
+	}
+	files, err := g.GenerateFiles(ctx)
 	if err != nil {
 		return nil, err
 	}
+	return files[0].Src, nil
+}
 
-	var src []byte
-	if g.config.Generator.EmitTypedFeatures {
-		src, err = renderTypedFeaturesGo(g.config.Generator.PackageName, features, g.config.Generator.EmitFeatureList)
-	} else {
-		src, err = renderFeatureKeysGo(g.config.Generator.PackageName, features, g.config.Generator.EmitFeatureList)
+// GenerateTo renders the generated package as a single file and writes it to w. Like Generate, it fails when
+// generator.split is set.
+func (g *Generator) GenerateTo(ctx context.Context, w io.Writer) error {
+	src, err := g.Generate(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(src)
+	return err
+}
+
+// GenerateFiles renders the generated package. The main file (generator.outputFile) comes first.
+func (g *Generator) GenerateFiles(ctx context.Context) ([]OutputFile, error) {
+	features, projects, err := g.loadFeatures(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	return src, err
+	sections, err := g.loadSections(ctx, features, projects)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.renderFiles(features, sections, projects.All, g.config.Hash())
+}
+
+// loadFeatures fetches and filters the features to generate, and returns the projects they were fetched from.
+func (g *Generator) loadFeatures(ctx context.Context) ([]featureMeta, projectScope, error) {
+	projects, err := g.loadProjects(ctx)
+	if err != nil {
+		return nil, projectScope{}, err
+	}
+	features, err := g.loadFeatureMeta(ctx, projects.IDs)
+	if err != nil {
+		return nil, projectScope{}, err
+	}
+	features, err = g.filterFeatures(features)
+	if err != nil {
+		return nil, projectScope{}, err
+	}
+	return features, projects, nil
+}
+
+// loadSections fetches and renders the optional declarations emitted after the features.
+func (g *Generator) loadSections(ctx context.Context, features []featureMeta, projects projectScope) ([]declSection, error) {
+	var sections []declSection
+	if g.config.Generator.EmitEnvironments {
+		envs, err := g.loadEnvironments(ctx, features, projects.IDs)
+		if err != nil {
+			return nil, err
+		}
+		sections = append(sections, renderEnvironments(envs))
+	}
+	if g.config.Generator.EmitProjects {
+		sections = append(sections, renderProjects(projects.All, projects.IDs))
+	}
+	if g.config.Generator.EmitSavedGroups {
+		groups, err := g.fetchSavedGroups(ctx, projects.IDs)
+		if err != nil {
+			return nil, err
+		}
+		sections = append(sections, renderSavedGroups(groups))
+	}
+	if g.config.Generator.EmitAttributes {
+		attrs, err := g.fetchAttributes(ctx, projects.IDs)
+		if err != nil {
+			return nil, err
+		}
+		section, err := renderAttributes(attrs)
+		if err != nil {
+			return nil, err
+		}
+		sections = append(sections, section)
+	}
+	if g.config.Generator.EmitExperiments {
+		experiments, err := g.loadExperiments(ctx, features)
+		if err != nil {
+			return nil, err
+		}
+		sections = append(sections, renderExperiments(experiments))
+	}
+	return sections, nil
+}
+
+// loadFeatureMeta reads feature definitions from the configured source. projectIDs limits the REST API source.
+func (g *Generator) loadFeatureMeta(ctx context.Context, projectIDs []string) ([]featureMeta, error) {
+	switch g.config.Generator.SourceMode() {
+	case config.SourceFile:
+		return loadSnapshotFeatureMeta(g.config.Generator.FromFile)
+	case config.SourceSDK:
+		return g.fetchSDKFeatureMeta(ctx)
+	default:
+		return g.fetchAllFeatureMeta(ctx, projectIDs)
+	}
+}
+
+// httpDoer returns the HTTP client used for requests made outside the generated API client.
+func (g *Generator) httpDoer() growthbookapi.HttpRequestDoer {
+	if g.httpClient == nil {
+		return http.DefaultClient
+	}
+	return g.httpClient
+}
+
+// secrets returns configured credentials that must never appear in errors.
+func (g *Generator) secrets() []string {
+	return []string{g.config.GrowthBook.APIKey, g.config.GrowthBook.ClientKey, g.config.GrowthBook.DecryptionKey}
 }
@@ -2,8 +2,16 @@ package generator
 
 import (
 	"context"
+	"encoding/base64"
+	"fmt"
+	"go/ast"
 	"go/format"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/eastnine90/gbgen/internal/config"
@@ -13,32 +21,94 @@ import (
 var _ growthbookapi.ClientWithResponsesInterface = (*mockFeaturesAPI)(nil)
 
 type mockFeaturesAPI struct {
-	t *testing.T
+	t  *testing.T
+	mu sync.Mutex
 
 	listCalls []listCall
 	keysCalls []keysCall
 
 	featuresRespByOffset map[int32]*growthbookapi.ListFeaturesResponse
+	// featuresRespByProject, when set, serves responses per project ID instead of featuresRespByOffset.
+	featuresRespByProject map[string]map[int32]*growthbookapi.ListFeaturesResponse
+
+	savedGroupsResp  *growthbookapi.ListSavedGroupsResponse
+	attributesResp   *growthbookapi.ListAttributesResponse
+	experimentsResp  *growthbookapi.ListExperimentsResponse
+	environmentsResp *growthbookapi.ListEnvironmentsResponse
+	projectsResp     *growthbookapi.ListProjectsResponse
 }
 
 func (m *mockFeaturesAPI) ListFeaturesWithResponse(ctx context.Context, params *growthbookapi.ListFeaturesParams, reqEditors ...growthbookapi.RequestEditorFn) (*growthbookapi.ListFeaturesResponse, error) {
-	m.listCalls = append(m.listCalls, listCall{limit: params.Limit, offset: params.Offset, projectID: params.ProjectId})
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.listCalls = append(m.listCalls, listCall{limit: params.Limit, offset: params.Offset, projectID: params.ProjectId, clientKey: params.ClientKey})
 
 	var offset int32
 	if params.Offset != nil {
 		offset = int32(*params.Offset)
 	}
-	resp := m.featuresRespByOffset[offset]
+	byOffset := m.featuresRespByOffset
+	if m.featuresRespByProject != nil {
+		if params.ProjectId == nil {
+			m.t.Errorf("expected a projectId")
+			return nil, fmt.Errorf("missing projectId")
+		}
+		byOffset = m.featuresRespByProject[*params.ProjectId]
+	}
+	resp := byOffset[offset]
 	if resp == nil {
-		m.t.Fatalf("unexpected offset %d", offset)
+		m.t.Errorf("unexpected offset %d", offset)
+		return nil, fmt.Errorf("unexpected offset %d", offset)
 	}
 	return resp, nil
 }
 
+func (m *mockFeaturesAPI) ListSavedGroupsWithResponse(ctx context.Context, params *growthbookapi.ListSavedGroupsParams, reqEditors ...growthbookapi.RequestEditorFn) (*growthbookapi.ListSavedGroupsResponse, error) {
+	if m.savedGroupsResp == nil {
+		m.t.Errorf("unexpected ListSavedGroups call")
+		return nil, fmt.Errorf("unexpected ListSavedGroups call")
+	}
+	return m.savedGroupsResp, nil
+}
+
+func (m *mockFeaturesAPI) ListAttributesWithResponse(ctx context.Context, reqEditors ...growthbookapi.RequestEditorFn) (*growthbookapi.ListAttributesResponse, error) {
+	if m.attributesResp == nil {
+		m.t.Errorf("unexpected ListAttributes call")
+		return nil, fmt.Errorf("unexpected ListAttributes call")
+	}
+	return m.attributesResp, nil
+}
+
+func (m *mockFeaturesAPI) ListExperimentsWithResponse(ctx context.Context, params *growthbookapi.ListExperimentsParams, reqEditors ...growthbookapi.RequestEditorFn) (*growthbookapi.ListExperimentsResponse, error) {
+	if m.experimentsResp == nil {
+		m.t.Errorf("unexpected ListExperiments call")
+		return nil, fmt.Errorf("unexpected ListExperiments call")
+	}
+	return m.experimentsResp, nil
+}
+
+func (m *mockFeaturesAPI) ListEnvironmentsWithResponse(ctx context.Context, reqEditors ...growthbookapi.RequestEditorFn) (*growthbookapi.ListEnvironmentsResponse, error) {
+	if m.environmentsResp == nil {
+		m.t.Errorf("unexpected ListEnvironments call")
+		return nil, fmt.Errorf("unexpected ListEnvironments call")
+	}
+	return m.environmentsResp, nil
+}
+
+func (m *mockFeaturesAPI) ListProjectsWithResponse(ctx context.Context, params *growthbookapi.ListProjectsParams, reqEditors ...growthbookapi.RequestEditorFn) (*growthbookapi.ListProjectsResponse, error) {
+	if m.projectsResp == nil {
+		m.t.Errorf("unexpected ListProjects call")
+		return nil, fmt.Errorf("unexpected ListProjects call")
+	}
+	return m.projectsResp, nil
+}
+
 type listCall struct {
 	limit     *int
 	offset    *int
 	projectID *string
+	clientKey *string
 }
 
 type keysCall struct {
@@ -82,7 +152,7 @@ func TestGeneratorGenerate_KeysOnly(t *testing.T) {
 							Environments: map[string]growthbookapi.FeatureEnvironment{
 								"production": {Enabled: true},
 							},
-							ValueType: growthbookapi.Boolean,
+							ValueType: growthbookapi.FeatureValueTypeBoolean,
 						},
 						{
 							Id:          "disabled-feature",
@@ -90,7 +160,7 @@ func TestGeneratorGenerate_KeysOnly(t *testing.T) {
 							Environments: map[string]growthbookapi.FeatureEnvironment{
 								"production": {Enabled: false},
 							},
-							ValueType: growthbookapi.Boolean,
+							ValueType: growthbookapi.FeatureValueTypeBoolean,
 						},
 					},
 				},
@@ -122,6 +192,47 @@ func TestGeneratorGenerate_KeysOnly(t *testing.T) {
 	if mock.listCalls[0].projectID == nil || *mock.listCalls[0].projectID != project {
 		t.Fatalf("expected projectID %q, got %#v", project, mock.listCalls[0].projectID)
 	}
+	if mock.listCalls[0].clientKey != nil {
+		t.Fatalf("expected nil clientKey, got %#v", mock.listCalls[0].clientKey)
+	}
+}
+
+func TestGeneratorGenerate_ClientKeyFilter(t *testing.T) {
+	cfg := config.Config{
+		GrowthBook: config.GrowthBookConfig{
+			APIBaseURL: "https://api.growthbook.io",
+			APIKey:     "secret_x",
+			ClientKey:  "sdk-abc123",
+		},
+		Generator: config.GeneratorConfig{
+			PackageName: "features",
+		},
+	}
+
+	mock := &mockFeaturesAPI{
+		t: t,
+		featuresRespByOffset: map[int32]*growthbookapi.ListFeaturesResponse{
+			0: listFeaturesResponse([]growthbookapi.Feature{
+				{
+					Id:           "checkout-redesign",
+					Environments: map[string]growthbookapi.FeatureEnvironment{"production": {Enabled: true}},
+					ValueType:    growthbookapi.FeatureValueTypeBoolean,
+				},
+			}, nil),
+		},
+	}
+
+	g := &Generator{api: mock, config: cfg}
+	if _, err := g.Generate(context.Background()); err != nil {
+		t.Fatalf("Generate error: %v", err)
+	}
+
+	if len(mock.listCalls) != 1 {
+		t.Fatalf("expected 1 list call, got %d", len(mock.listCalls))
+	}
+	if mock.listCalls[0].clientKey == nil || *mock.listCalls[0].clientKey != "sdk-abc123" {
+		t.Fatalf("expected clientKey %q, got %#v", "sdk-abc123", mock.listCalls[0].clientKey)
+	}
 }
 
 func TestGeneratorGenerate_KeysOnly_NoFeatureList(t *testing.T) {
@@ -161,7 +272,7 @@ func TestGeneratorGenerate_KeysOnly_NoFeatureList(t *testing.T) {
 							Environments: map[string]growthbookapi.FeatureEnvironment{
 								"production": {Enabled: true},
 							},
-							ValueType: growthbookapi.Boolean,
+							ValueType: growthbookapi.FeatureValueTypeBoolean,
 						},
 					},
 				},
@@ -183,6 +294,39 @@ func TestGeneratorGenerate_KeysOnly_NoFeatureList(t *testing.T) {
 	assertNotContains(t, out, "FeatureList")
 }
 
+func TestGeneratorGenerateTo(t *testing.T) {
+	features := []growthbookapi.Feature{{Id: "checkout-redesign", ValueType: growthbookapi.FeatureValueTypeBoolean}}
+	newMock := func() *mockFeaturesAPI {
+		return &mockFeaturesAPI{
+			t:                    t,
+			featuresRespByOffset: map[int32]*growthbookapi.ListFeaturesResponse{0: listFeaturesResponse(features, nil)},
+		}
+	}
+	cfg := config.Config{Generator: config.GeneratorConfig{PackageName: "features"}}
+
+	want, err := (&Generator{api: newMock(), config: cfg}).Generate(context.Background())
+	if err != nil {
+		t.Fatalf("Generate error: %v", err)
+	}
+	var buf strings.Builder
+	if err := (&Generator{api: newMock(), config: cfg}).GenerateTo(context.Background(), &buf); err != nil {
+		t.Fatalf("GenerateTo error: %v", err)
+	}
+	if buf.String() != string(want) {
+		t.Fatalf("GenerateTo wrote:\n%s\nwant:\n%s", buf.String(), want)
+	}
+
+	cfg.Generator.Split = config.SplitTag
+	buf.Reset()
+	err = (&Generator{api: newMock(), config: cfg}).GenerateTo(context.Background(), &buf)
+	if err == nil || !strings.Contains(err.Error(), "generator.split") {
+		t.Fatalf("GenerateTo with split: err = %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("GenerateTo with split wrote %q", buf.String())
+	}
+}
+
 func TestGeneratorGenerate_Typed(t *testing.T) {
 	cfg := config.Config{
 		GrowthBook: config.GrowthBookConfig{
@@ -219,7 +363,7 @@ func TestGeneratorGenerate_Typed(t *testing.T) {
 							Environments: map[string]growthbookapi.FeatureEnvironment{
 								"production": {Enabled: true},
 							},
-							ValueType: growthbookapi.String,
+							ValueType: growthbookapi.FeatureValueTypeString,
 						},
 					},
 				},
@@ -284,7 +428,7 @@ func TestGeneratorGenerate_Typed_NoFeatureList(t *testing.T) {
 							Environments: map[string]growthbookapi.FeatureEnvironment{
 								"production": {Enabled: true},
 							},
-							ValueType: growthbookapi.String,
+							ValueType: growthbookapi.FeatureValueTypeString,
 						},
 					},
 				},
@@ -321,6 +465,42 @@ func assertNotContains(t *testing.T, s, substr string) {
 	}
 }
 
+// assertUniqueDecls fails when src declares a package-level name more than once.
+func assertUniqueDecls(t *testing.T, src []byte) {
+	t.Helper()
+
+	file, err := parser.ParseFile(token.NewFileSet(), "", src, parser.SkipObjectResolution)
+	if err != nil {
+		t.Fatalf("parse generated output: %v", err)
+	}
+	seen := map[string]bool{}
+	declare := func(name string) {
+		if seen[name] {
+			t.Fatalf("generated output declares %s more than once\n---\n%s\n---", name, src)
+		}
+		seen[name] = true
+	}
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Recv == nil {
+				declare(d.Name.Name)
+			}
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					declare(s.Name.Name)
+				case *ast.ValueSpec:
+					for _, n := range s.Names {
+						declare(n.Name)
+					}
+				}
+			}
+		}
+	}
+}
+
 func assertGofmtIdempotent(t *testing.T, src []byte) {
 	t.Helper()
 
@@ -332,3 +512,43 @@ func assertGofmtIdempotent(t *testing.T, src []byte) {
 		t.Fatalf("generated output is not gofmt-idempotent (format.Source would change it)\n--- before ---\n%s\n--- after ---\n%s\n", string(src), string(formatted))
 	}
 }
+
+func TestNewGenerator_AuthMethods(t *testing.T) {
+	tests := []struct {
+		method string
+		want   string
+	}{
+		{method: config.AuthBearer, want: "Bearer secret_abc"},
+		{method: config.AuthBasic, want: "Basic " + base64.StdEncoding.EncodeToString([]byte("secret_abc:"))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			var got string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header.Get("Authorization")
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"features":[],"hasMore":false}`))
+			}))
+			defer srv.Close()
+
+			g, err := NewGenerator(config.Config{
+				GrowthBook: config.GrowthBookConfig{
+					APIBaseURL: srv.URL,
+					APIKey:     "secret_abc",
+					Auth:       config.AuthConfig{Method: tt.method},
+				},
+				Generator: config.GeneratorConfig{PackageName: "features"},
+			})
+			if err != nil {
+				t.Fatalf("NewGenerator error: %v", err)
+			}
+			if _, err := g.Generate(context.Background()); err != nil {
+				t.Fatalf("Generate error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("Authorization = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -9,9 +9,14 @@ import (
 	"github.com/eastnine90/gbgen/internal/buildinfo"
 )
 
-func renderFeatureKeysGo(pkg string, features []featureMeta, emitList bool) ([]byte, error) {
+func renderFeatureKeysGo(pkg string, features []featureMeta, emitList bool, sections ...declSection) ([]byte, error) {
 	lines := nameAndDedupe(features)
+	return renderFeatureKeysFile(pkg, lines, lines, emitList, sections)
+}
 
+// renderFeatureKeysFile renders the main output file: the package doc, the shared declarations, the constants of own
+// and the feature list of all features.
+func renderFeatureKeysFile(pkg string, own, all []namedFeature, emitList bool, sections []declSection) ([]byte, error) {
 	pkgName := pkg
 	if pkgName == "" {
 		pkgName = "features"
@@ -29,6 +34,7 @@ func renderFeatureKeysGo(pkg string, features []featureMeta, emitList bool) ([]b
 			"\t// Use the generated keys with your GrowthBook SDK wrapper / evaluator.",
 			"\t_ = " + "FeatureKey(\"example\")",
 		},
+		Imports: sectionImports(nil, sections),
 	})
 	if err != nil {
 		return nil, err
@@ -39,36 +45,98 @@ func renderFeatureKeysGo(pkg string, features []featureMeta, emitList bool) ([]b
 
 	fmt.Fprintf(&b, "type FeatureKey string\n\n")
 	fmt.Fprintf(&b, "func (f FeatureKey) Key() string { return string(f) }\n")
-	fmt.Fprintf(&b, "const (\n")
-	for _, l := range lines {
-		desc := strings.TrimSpace(l.Description)
-		if desc != "" {
-			for _, line := range strings.Split(desc, "\n") {
-				line = strings.TrimSpace(line)
-				if line == "" {
-					continue
-				}
-				fmt.Fprintf(&b, "\t// %s\n", line)
-			}
-		}
-		if l.NoActiveEnvs {
-			fmt.Fprintf(&b, "\t// Deprecated: no active environments\n")
-		}
-		fmt.Fprintf(&b, "\t%s FeatureKey = %q\n", l.Name, l.ID)
+	// With generator.split every feature may live in another file; an empty package keeps its empty const block.
+	if len(own) > 0 || len(all) == 0 {
+		writeFeatureKeyConsts(&b, own)
 	}
-	fmt.Fprintf(&b, ")\n\n")
+	fmt.Fprintf(&b, "\n")
 
 	if emitList {
 		fmt.Fprintf(&b, "var FeatureList = []FeatureKey{\n")
-		for _, l := range lines {
+		for _, l := range all {
 			fmt.Fprintf(&b, "\t%s,\n", l.Name)
 		}
 		fmt.Fprintf(&b, "}\n\n")
 	}
 
+	for _, s := range sections {
+		b.Write(s.Body)
+		fmt.Fprintf(&b, "\n")
+	}
+
 	return formatGo(b.Bytes())
 }
 
+// renderFeatureKeysPart renders a file holding only the constants of lines (see generator.split).
+func renderFeatureKeysPart(pkg string, lines []namedFeature) ([]byte, error) {
+	// Only the main file carries the package doc comment.
+	header, err := renderPreamble(preambleOptions{PackageName: pkg, GBGenVersion: buildinfo.Version, Imports: nil})
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	b.Write(header)
+	writeFeatureKeyConsts(&b, lines)
+	return formatGo(b.Bytes())
+}
+
+func writeFeatureKeyConsts(b *bytes.Buffer, lines []namedFeature) {
+	fmt.Fprintf(b, "const (\n")
+	for _, l := range lines {
+		writeFeatureDoc(b, l)
+		fmt.Fprintf(b, "\t%s FeatureKey = %q\n", l.Name, l.ID)
+	}
+	fmt.Fprintf(b, ")\n")
+}
+
+// writeFeatureDoc writes the doc comment of a generated feature constant.
+func writeFeatureDoc(b *bytes.Buffer, f namedFeature) {
+	var paragraphs []string
+	if desc := strings.TrimSpace(f.Description); desc != "" {
+		var lines []string
+		for _, line := range strings.Split(desc, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				lines = append(lines, line)
+			}
+		}
+		paragraphs = append(paragraphs, strings.Join(lines, "\n"))
+	}
+	if f.Project != "" {
+		paragraphs = append(paragraphs, "Project: "+f.Project)
+	}
+	if len(f.EnabledIn) > 0 {
+		paragraphs = append(paragraphs, "Enabled in: "+strings.Join(f.EnabledIn, ", "))
+	}
+	if notice := deprecationNotice(f.featureMeta); notice != "" {
+		paragraphs = append(paragraphs, "Deprecated: "+notice)
+	}
+
+	// Paragraphs are separated by an empty comment line so tools (e.g. staticcheck) recognize "Deprecated:".
+	for i, p := range paragraphs {
+		if i > 0 {
+			fmt.Fprintf(b, "\t//\n")
+		}
+		for _, line := range strings.Split(p, "\n") {
+			fmt.Fprintf(b, "\t// %s\n", line)
+		}
+	}
+}
+
+// deprecationNotice returns why a feature is deprecated, or "" if it is not.
+func deprecationNotice(f featureMeta) string {
+	switch {
+	case f.Archived:
+		return "archived in GrowthBook"
+	case f.NoActiveEnvs && len(f.CheckedEnvs) > 0:
+		return "not enabled in " + strings.Join(f.CheckedEnvs, ", ")
+	case f.NoActiveEnvs:
+		return "no active environments"
+	default:
+		return ""
+	}
+}
+
 func toExportedIdentifier(featureID string) string {
 	// Split on non-alphanumeric and PascalCase the parts.
 	parts := splitNonAlnum(featureID)
@@ -0,0 +1,109 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"strings"
+
+	"github.com/eastnine90/gbgen/internal/buildinfo"
+)
+
+// manifestDirective starts the comment that embeds the manifest in the main generated file.
+const manifestDirective = "//gbgen:manifest"
+
+// manifestVersion is the version of the manifest format.
+const manifestVersion = 1
+
+// manifest records what a generation produced (generator.emitManifest), so tools can read it back without calling
+// the GrowthBook API or guessing from identifiers.
+type manifest struct {
+	Version      int               `json:"version"`
+	GBGenVersion string            `json:"gbgenVersion"`
+	ConfigHash   string            `json:"configHash"`
+	Features     []manifestFeature `json:"features"`
+}
+
+type manifestFeature struct {
+	ID         string `json:"id"`
+	ValueType  string `json:"valueType"`
+	Identifier string `json:"identifier"`
+	Project    string `json:"project,omitempty"`
+	Revision   int    `json:"revision,omitempty"`
+	// File is the generated file that declares the feature, when generator.split is set.
+	File string `json:"file,omitempty"`
+}
+
+// newManifest describes the generated features. files maps feature IDs to their file when the output is split.
+func newManifest(features []namedFeature, files map[string]string, configHash string) manifest {
+	m := manifest{
+		Version:      manifestVersion,
+		GBGenVersion: buildinfo.Version,
+		ConfigHash:   configHash,
+		Features:     make([]manifestFeature, 0, len(features)),
+	}
+	for _, f := range features {
+		m.Features = append(m.Features, manifestFeature{
+			ID:         f.ID,
+			ValueType:  string(f.ValueType),
+			Identifier: f.Name,
+			Project:    f.Project,
+			Revision:   f.Revision,
+			File:       files[f.ID],
+		})
+	}
+	return m
+}
+
+// renderManifest embeds m as a comment at the end of the main file. The comment lines after the directive hold the
+// manifest as JSON, one feature per line so that regenerating changes one line per changed feature.
+func renderManifest(m manifest) (declSection, error) {
+	head, err := json.Marshal(struct {
+		Version      int    `json:"version"`
+		GBGenVersion string `json:"gbgenVersion"`
+		ConfigHash   string `json:"configHash"`
+	}{m.Version, m.GBGenVersion, m.ConfigHash})
+	if err != nil {
+		return declSection{}, err
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "%s\n", manifestDirective)
+	fmt.Fprintf(&b, "// %s,\"features\":[\n", bytes.TrimSuffix(head, []byte("}")))
+	for i, f := range m.Features {
+		line, err := json.Marshal(f)
+		if err != nil {
+			return declSection{}, err
+		}
+		sep := ","
+		if i == len(m.Features)-1 {
+			sep = ""
+		}
+		fmt.Fprintf(&b, "// %s%s\n", line, sep)
+	}
+	fmt.Fprintf(&b, "// ]}\n")
+	return declSection{Body: b.Bytes()}, nil
+}
+
+// parseManifest returns the manifest embedded in a parsed generated file, or nil when it has none.
+func parseManifest(file *ast.File) (*manifest, error) {
+	for _, group := range file.Comments {
+		if len(group.List) == 0 || group.List[0].Text != manifestDirective {
+			continue
+		}
+		var src strings.Builder
+		for _, c := range group.List[1:] {
+			src.WriteString(strings.TrimPrefix(c.Text, "//"))
+		}
+		var m manifest
+		if err := json.Unmarshal([]byte(src.String()), &m); err != nil {
+			return nil, fmt.Errorf("gbgen manifest: %w", err)
+		}
+		if m.Version != manifestVersion {
+			return nil, fmt.Errorf("gbgen manifest: unsupported version %d", m.Version)
+		}
+		return &m, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,103 @@
+package generator
+
+import (
+	"context"
+	"encoding/json"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"testing"
+
+	"github.com/eastnine90/gbgen/internal/buildinfo"
+	"github.com/eastnine90/gbgen/internal/config"
+	"github.com/eastnine90/gbgen/internal/growthbookapi"
+)
+
+func TestGeneratorGenerate_Manifest(t *testing.T) {
+	var features []growthbookapi.Feature
+	if err := json.Unmarshal([]byte(`[
+  {"id": "checkout-v2", "valueType": "boolean", "project": "prj_web", "tags": ["payments"], "revision": {"version": 7}},
+  {"id": "banner-color", "valueType": "string", "revision": {"version": 2}},
+  {"id": "quote\"id", "valueType": "json"}
+]`), &features); err != nil {
+		t.Fatalf("unmarshal features: %v", err)
+	}
+
+	for _, split := range []string{"", config.SplitTag} {
+		mock := &mockFeaturesAPI{
+			t:                    t,
+			featuresRespByOffset: map[int32]*growthbookapi.ListFeaturesResponse{0: listFeaturesResponse(features, nil)},
+		}
+		cfg := config.Config{Generator: config.GeneratorConfig{PackageName: "features", EmitManifest: true, Split: split}}
+		files, err := (&Generator{api: mock, config: cfg}).GenerateFiles(context.Background())
+		if err != nil {
+			t.Fatalf("GenerateFiles (split=%q) error: %v", split, err)
+		}
+		src := files[0].Src
+		assertGofmtIdempotent(t, src)
+		assertContains(t, string(src), "\n//gbgen:manifest\n// {\"version\":1,")
+		assertContains(t, string(src), "\n// {\"id\":\"banner-color\",\"valueType\":\"string\",\"identifier\":\"FeatureBannerColor\",\"revision\":2")
+		for _, f := range files[1:] {
+			assertNotContains(t, string(f.Src), manifestDirective)
+		}
+
+		file, err := parser.ParseFile(token.NewFileSet(), "", src, parser.ParseComments)
+		if err != nil {
+			t.Fatalf("parse: %v", err)
+		}
+		m, err := parseManifest(file)
+		if err != nil || m == nil {
+			t.Fatalf("parseManifest (split=%q) = %v, %v", split, m, err)
+		}
+		checkoutFile := ""
+		if split != "" {
+			checkoutFile = "features_payments.gen.go"
+		}
+		want := manifest{
+			Version:      manifestVersion,
+			GBGenVersion: buildinfo.Version,
+			ConfigHash:   cfg.Hash(),
+			Features: []manifestFeature{
+				{ID: "banner-color", ValueType: "string", Identifier: "FeatureBannerColor", Revision: 2, File: fileIf(split, "features.gen.go")},
+				{ID: "checkout-v2", ValueType: "boolean", Identifier: "FeatureCheckoutV2", Project: "prj_web", Revision: 7, File: checkoutFile},
+				{ID: "quote\"id", ValueType: "json", Identifier: "FeatureQuoteId", File: fileIf(split, "features.gen.go")},
+			},
+		}
+		if !reflect.DeepEqual(*m, want) {
+			t.Fatalf("manifest (split=%q):\n%+v\nwant:\n%+v", split, *m, want)
+		}
+
+		// Keys-only code records value types only in the manifest.
+		parsed, err := ParseGeneratedFeatures(src)
+		if err != nil {
+			t.Fatalf("ParseGeneratedFeatures error: %v", err)
+		}
+		for _, f := range parsed {
+			if f.ValueType == "" {
+				t.Fatalf("ParseGeneratedFeatures (split=%q): no value type for %q", split, f.ID)
+			}
+		}
+	}
+}
+
+func TestGeneratorGenerate_NoManifestByDefault(t *testing.T) {
+	mock := &mockFeaturesAPI{
+		t: t,
+		featuresRespByOffset: map[int32]*growthbookapi.ListFeaturesResponse{
+			0: listFeaturesResponse([]growthbookapi.Feature{{Id: "a", ValueType: growthbookapi.FeatureValueTypeBoolean}}, nil),
+		},
+	}
+	src, err := (&Generator{api: mock, config: config.Config{Generator: config.GeneratorConfig{PackageName: "features"}}}).Generate(context.Background())
+	if err != nil {
+		t.Fatalf("Generate error: %v", err)
+	}
+	assertNotContains(t, string(src), manifestDirective)
+}
+
+// fileIf returns name when the output is split.
+func fileIf(split, name string) string {
+	if split == "" {
+		return ""
+	}
+	return name
+}
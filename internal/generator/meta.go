@@ -4,66 +4,162 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"sync"
 
+	"github.com/eastnine90/gbgen/internal/config"
 	"github.com/eastnine90/gbgen/internal/growthbookapi"
 )
 
 type featureMeta struct {
-	ID           string
-	Description  string
+	ID          string
+	Description string
+	ValueType   growthbookapi.FeatureValueType
+	Tags        []string
+
+	// Environments maps environment ID to enabled state; nil when the source has no environment information.
+	Environments map[string]bool
 	NoActiveEnvs bool
-	ValueType    growthbookapi.FeatureValueType
+	// CheckedEnvs and EnabledIn are set by the filter stage when generator.environments is configured.
+	CheckedEnvs []string
+	EnabledIn   []string
+
+	// Archived is cleared by the filter stage unless generator.archivedPolicy is "deprecate".
+	Archived bool
+	// Project is the feature's GrowthBook project ("" if none or unknown). It is shown in the doc comment and used by
+	// generator.split.
+	Project string
+	// NameID, when set, replaces ID as the base of the generated identifier (see generator.stripPrefix).
+	NameID string
+	// Experiments are the experiments the feature's rules refer to (REST API and snapshot sources only).
+	Experiments []experimentRef
+	// Revision is the published revision version (REST API and snapshot sources only).
+	Revision int
+}
+
+// baseName returns the generated identifier before dedupe suffixes.
+func (f featureMeta) baseName() string {
+	id := f.ID
+	if f.NameID != "" {
+		id = f.NameID
+	}
+	return "Feature" + toExportedIdentifier(id)
 }
 
 type namedFeature struct {
-	Name         string
-	ID           string
-	Description  string
-	NoActiveEnvs bool
-	ValueType    growthbookapi.FeatureValueType
+	featureMeta
+	Name string
 }
 
-func (g *Generator) fetchAllFeatureMeta(ctx context.Context) ([]featureMeta, error) {
-	limit := 100
-	offset := 0
+func (g *Generator) fetchAllFeatureMeta(ctx context.Context, projects []string) ([]featureMeta, error) {
+	if len(projects) > 1 {
+		return g.fetchMultiProjectFeatureMeta(ctx, projects)
+	}
 
-	var out []featureMeta
-	for {
-		resp, err := g.api.ListFeaturesWithResponse(ctx, &growthbookapi.ListFeaturesParams{
-			Limit:     &limit,
-			Offset:    &offset,
-			ProjectId: g.config.GrowthBook.ProjectID,
-			ClientKey: nil,
-		})
-		if err != nil {
-			return nil, err
-		}
-		if resp == nil {
-			return nil, fmt.Errorf("list features: empty response")
-		}
+	var projectID *string
+	if len(projects) == 1 {
+		projectID = &projects[0]
+	}
+	features, err := g.listAllFeatures(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := uniqueFeatureMeta(features)
+	sortFeatureMeta(out)
+	return out, nil
+}
 
-		for _, f := range resp.JSON200.Features {
-			if f.Id == "" {
+// fetchMultiProjectFeatureMeta lists each project concurrently and merges the results.
+//
+// A feature ID returned for more than one project is resolved by generator.onDuplicateFeature:
+// "first" keeps the definition from the project listed first, "error" fails generation.
+func (g *Generator) fetchMultiProjectFeatureMeta(ctx context.Context, projects []string) ([]featureMeta, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([][]featureMeta, len(projects))
+	var (
+		wg       sync.WaitGroup
+		failOnce sync.Once
+		firstErr error
+	)
+	for i, project := range projects {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			features, err := g.listAllFeatures(ctx, &project)
+			if err != nil {
+				failOnce.Do(func() {
+					firstErr = fmt.Errorf("project %q: %w", project, err)
+					cancel()
+				})
+				return
+			}
+			results[i] = uniqueFeatureMeta(features)
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	owner := map[string]string{}
+	var out []featureMeta
+	for i, features := range results {
+		for _, f := range features {
+			if first, ok := owner[f.ID]; ok {
+				if g.config.Generator.OnDuplicateFeature == config.DuplicateError {
+					return nil, fmt.Errorf("feature %q is defined in projects %q and %q (generator.onDuplicateFeature=error)", f.ID, first, projects[i])
+				}
 				continue
 			}
-			out = append(out, featureMeta{
-				ID:           f.Id,
-				Description:  f.Description,
-				NoActiveEnvs: featureHasNoActiveEnvironments(f.Environments),
-				ValueType:    f.ValueType,
-			})
+			owner[f.ID] = projects[i]
+			f.Project = projects[i]
+			out = append(out, f)
 		}
+	}
 
-		if !resp.JSON200.HasMore {
-			break
+	sortFeatureMeta(out)
+	return out, nil
+}
+
+// uniqueFeatureMeta converts API features, dropping empty and repeated IDs.
+func uniqueFeatureMeta(features []growthbookapi.Feature) []featureMeta {
+	seen := map[string]struct{}{}
+	var out []featureMeta
+	for _, f := range features {
+		if f.Id == "" {
+			continue
 		}
-		if resp.JSON200.NextOffset != nil {
-			offset = *resp.JSON200.NextOffset
+		// Pages fetched while features are being created may overlap.
+		if _, ok := seen[f.Id]; ok {
+			continue
 		}
+		seen[f.Id] = struct{}{}
+		out = append(out, newFeatureMeta(f))
+	}
+	return out
+}
+
+// newFeatureMeta extracts the fields the renderers need from an API feature definition.
+func newFeatureMeta(f growthbookapi.Feature) featureMeta {
+	return featureMeta{
+		ID:           f.Id,
+		Description:  f.Description,
+		NoActiveEnvs: featureHasNoActiveEnvironments(f.Environments),
+		Environments: featureEnvironmentStates(f.Environments),
+		ValueType:    f.ValueType,
+		Tags:         f.Tags,
+		Archived:     f.Archived,
+		Project:      f.Project,
+		Experiments:  featureExperimentRefs(f.Environments),
+		Revision:     f.Revision.Version,
 	}
+}
 
-	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
-	return out, nil
+// sortFeatureMeta orders features by ID so output is stable regardless of source order.
+func sortFeatureMeta(features []featureMeta) {
+	sort.Slice(features, func(i, j int) bool { return features[i].ID < features[j].ID })
 }
 
 func nameAndDedupe(features []featureMeta) []namedFeature {
@@ -71,24 +167,26 @@ func nameAndDedupe(features []featureMeta) []namedFeature {
 	out := make([]namedFeature, 0, len(features))
 
 	for _, f := range features {
-		baseName := "Feature" + toExportedIdentifier(f.ID)
+		baseName := f.baseName()
 		nameCounts[baseName]++
 		name := baseName
 		if nameCounts[baseName] > 1 {
 			name = fmt.Sprintf("%s_%d", baseName, nameCounts[baseName])
 		}
-		out = append(out, namedFeature{
-			Name:         name,
-			ID:           f.ID,
-			Description:  f.Description,
-			NoActiveEnvs: f.NoActiveEnvs,
-			ValueType:    f.ValueType,
-		})
+		out = append(out, namedFeature{featureMeta: f, Name: name})
 	}
 
 	return out
 }
 
+func featureEnvironmentStates(envs map[string]growthbookapi.FeatureEnvironment) map[string]bool {
+	out := make(map[string]bool, len(envs))
+	for id, e := range envs {
+		out[id] = e.Enabled
+	}
+	return out
+}
+
 func featureHasNoActiveEnvironments(envs map[string]growthbookapi.FeatureEnvironment) bool {
 	if len(envs) == 0 {
 		return true
@@ -0,0 +1,186 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/eastnine90/gbgen/internal/growthbookapi"
+)
+
+const (
+	defaultPageSize = 100
+	maxPageSize     = 100
+)
+
+// featuresPage is one page of a ListFeatures response.
+type featuresPage struct {
+	offset     int
+	features   []growthbookapi.Feature
+	hasMore    bool
+	nextOffset *int
+	limit      int
+	total      int
+}
+
+// listAllFeatures lists every feature of a project (nil: all projects).
+//
+// The first page is fetched on its own; once it reports the total, the remaining offsets are fetched by
+// a bounded worker pool (growthbook.concurrency) and concatenated in offset order, so the result does not
+// depend on completion order. If the inventory grew while fetching, the rest is walked sequentially.
+func (g *Generator) listAllFeatures(ctx context.Context, projectID *string) ([]growthbookapi.Feature, error) {
+	pageSize := g.config.GrowthBook.PageSize
+	if pageSize <= 0 || pageSize > maxPageSize {
+		pageSize = defaultPageSize
+	}
+
+	first, err := g.listFeaturesPage(ctx, projectID, 0, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	out := append([]growthbookapi.Feature(nil), first.features...)
+	last := first
+
+	if first.hasMore && g.config.GrowthBook.Concurrency > 1 && first.total > 0 {
+		offsets, err := remainingOffsets(first, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		pages, err := g.listFeaturesPages(ctx, projectID, offsets, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range pages {
+			out = append(out, p.features...)
+		}
+		if len(pages) > 0 {
+			last = pages[len(pages)-1]
+		}
+	}
+
+	for last.hasMore {
+		offset, err := nextPageOffset(last.offset, last.nextOffset, len(last.features))
+		if err != nil {
+			return nil, fmt.Errorf("list features: %w", err)
+		}
+		last, err = g.listFeaturesPage(ctx, projectID, offset, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, last.features...)
+	}
+
+	return out, nil
+}
+
+// remainingOffsets computes the offsets of all pages after the first one from the reported total.
+func remainingOffsets(first featuresPage, pageSize int) ([]int, error) {
+	start, err := nextPageOffset(first.offset, first.nextOffset, len(first.features))
+	if err != nil {
+		return nil, fmt.Errorf("list features: %w", err)
+	}
+	// The server may cap the page size below what was requested.
+	step := pageSize
+	if first.limit > 0 && first.limit < step {
+		step = first.limit
+	}
+
+	var offsets []int
+	for offset := start; offset < first.total; offset += step {
+		offsets = append(offsets, offset)
+	}
+	return offsets, nil
+}
+
+// listFeaturesPages fetches the given offsets with at most growthbook.concurrency requests in flight.
+// Pages are returned in the order of offsets.
+func (g *Generator) listFeaturesPages(ctx context.Context, projectID *string, offsets []int, pageSize int) ([]featuresPage, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pages := make([]featuresPage, len(offsets))
+	sem := make(chan struct{}, g.config.GrowthBook.Concurrency)
+
+	var (
+		wg       sync.WaitGroup
+		failOnce sync.Once
+		firstErr error
+	)
+	for i, offset := range offsets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			defer func() { <-sem }()
+
+			page, err := g.listFeaturesPage(ctx, projectID, offset, pageSize)
+			if err != nil {
+				// Keep the error that caused the cancellation, not the cancellations it caused.
+				failOnce.Do(func() {
+					firstErr = err
+					cancel()
+				})
+				return
+			}
+			pages[i] = page
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return pages, nil
+}
+
+func (g *Generator) listFeaturesPage(ctx context.Context, projectID *string, offset, limit int) (featuresPage, error) {
+	var clientKey *string
+	if g.config.GrowthBook.ClientKey != "" {
+		clientKey = &g.config.GrowthBook.ClientKey
+	}
+
+	resp, err := g.api.ListFeaturesWithResponse(ctx, &growthbookapi.ListFeaturesParams{
+		Limit:     &limit,
+		Offset:    &offset,
+		ProjectId: projectID,
+		ClientKey: clientKey,
+	})
+	if err != nil {
+		return featuresPage{}, redactTransportError(err, g.secrets()...)
+	}
+	if resp == nil {
+		return featuresPage{}, fmt.Errorf("list features: empty response")
+	}
+	if resp.JSON200 == nil {
+		return featuresPage{}, fmt.Errorf("list features: %w", newAPIError(resp.HTTPResponse, resp.Body, restAPIHint, g.secrets()...))
+	}
+
+	return featuresPage{
+		offset:     offset,
+		features:   resp.JSON200.Features,
+		hasMore:    resp.JSON200.HasMore,
+		nextOffset: resp.JSON200.NextOffset,
+		limit:      resp.JSON200.Limit,
+		total:      resp.JSON200.Total,
+	}, nil
+}
+
+// nextPageOffset returns the offset of the next page, guarding against pagination that would not advance.
+// A missing nextOffset falls back to the number of items received.
+func nextPageOffset(offset int, nextOffset *int, received int) (int, error) {
+	next := offset + received
+	if nextOffset != nil {
+		next = *nextOffset
+	}
+	if next <= offset {
+		return 0, fmt.Errorf("pagination does not advance (hasMore=true at offset %d, nextOffset %d)", offset, next)
+	}
+	return next, nil
+}
@@ -0,0 +1,74 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/eastnine90/gbgen/internal/config"
+	"github.com/eastnine90/gbgen/internal/growthbookapi"
+)
+
+func TestGeneratorGenerate_ConcurrentPagesMatchSequential(t *testing.T) {
+	const (
+		total    = 23
+		pageSize = 5
+	)
+
+	pages := map[int32]*growthbookapi.ListFeaturesResponse{}
+	for offset := 0; offset < total; offset += pageSize {
+		var features []growthbookapi.Feature
+		for i := offset; i < min(offset+pageSize, total); i++ {
+			features = append(features, growthbookapi.Feature{
+				Id:           fmt.Sprintf("feature-%02d", i),
+				Environments: map[string]growthbookapi.FeatureEnvironment{"production": {Enabled: i%3 != 0}},
+				ValueType:    growthbookapi.FeatureValueTypeBoolean,
+			})
+		}
+		var next *int
+		if offset+pageSize < total {
+			n := offset + pageSize
+			next = &n
+		}
+		resp := listFeaturesResponse(features, next)
+		resp.JSON200.Offset = offset
+		resp.JSON200.Limit = pageSize
+		resp.JSON200.Total = total
+		pages[int32(offset)] = resp
+	}
+
+	generate := func(concurrency int) ([]byte, []int) {
+		t.Helper()
+		cfg := config.Config{
+			GrowthBook: config.GrowthBookConfig{PageSize: pageSize, Concurrency: concurrency},
+			Generator:  config.GeneratorConfig{PackageName: "features", EmitFeatureList: true},
+		}
+		mock := &mockFeaturesAPI{t: t, featuresRespByOffset: pages}
+		src, err := (&Generator{api: mock, config: cfg}).Generate(context.Background())
+		if err != nil {
+			t.Fatalf("Generate (concurrency=%d) error: %v", concurrency, err)
+		}
+		var offsets []int
+		for _, c := range mock.listCalls {
+			if *c.limit != pageSize {
+				t.Fatalf("limit = %d, want %d", *c.limit, pageSize)
+			}
+			offsets = append(offsets, *c.offset)
+		}
+		sort.Ints(offsets)
+		return src, offsets
+	}
+
+	sequential, seqOffsets := generate(1)
+	concurrent, conOffsets := generate(3)
+
+	if !bytes.Equal(sequential, concurrent) {
+		t.Fatalf("concurrent output differs from sequential output\n--- sequential ---\n%s\n--- concurrent ---\n%s", sequential, concurrent)
+	}
+	want := []int{0, 5, 10, 15, 20}
+	if fmt.Sprint(seqOffsets) != fmt.Sprint(want) || fmt.Sprint(conOffsets) != fmt.Sprint(want) {
+		t.Fatalf("offsets: sequential %v, concurrent %v, want %v", seqOffsets, conOffsets, want)
+	}
+}
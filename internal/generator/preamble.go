@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"go/format"
+	"slices"
 	"sort"
 	"strings"
 )
@@ -53,6 +54,26 @@ func renderPreamble(opts preambleOptions) ([]byte, error) {
 	return b.Bytes(), nil
 }
 
+// declSection is a block of declarations appended to a generated file after the feature declarations,
+// together with the imports it needs.
+type declSection struct {
+	Imports []string
+	Body    []byte
+}
+
+// sectionImports returns base plus the imports of all sections, without duplicates.
+func sectionImports(base []string, sections []declSection) []string {
+	out := append([]string(nil), base...)
+	for _, s := range sections {
+		for _, imp := range s.Imports {
+			if !slices.Contains(out, imp) {
+				out = append(out, imp)
+			}
+		}
+	}
+	return out
+}
+
 func formatGo(src []byte) ([]byte, error) {
 	out, err := format.Source(src)
 	if err != nil {
@@ -0,0 +1,179 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/eastnine90/gbgen/internal/config"
+	"github.com/eastnine90/gbgen/internal/growthbookapi"
+)
+
+type projectMeta struct {
+	ID          string
+	Name        string
+	Description string
+}
+
+// projectScope is what loadProjects resolved for a run.
+type projectScope struct {
+	// IDs are the projects to generate from: growthbook.projectIDs, growthbook.projectID and the IDs of
+	// growthbook.projectNames. Empty means all projects.
+	IDs []string
+	// All lists every project when they were needed, nil otherwise.
+	All []projectMeta
+}
+
+// fetchProjects lists all projects, ordered by name then ID.
+func (g *Generator) fetchProjects(ctx context.Context) ([]projectMeta, error) {
+	pageSize := g.config.GrowthBook.PageSize
+	if pageSize <= 0 || pageSize > maxPageSize {
+		pageSize = defaultPageSize
+	}
+
+	seen := map[string]struct{}{}
+	var out []projectMeta
+	for offset := 0; ; {
+		resp, err := g.api.ListProjectsWithResponse(ctx, &growthbookapi.ListProjectsParams{
+			Limit:  &pageSize,
+			Offset: &offset,
+		})
+		if err != nil {
+			return nil, redactTransportError(err, g.secrets()...)
+		}
+		if resp == nil {
+			return nil, fmt.Errorf("list projects: empty response")
+		}
+		if resp.JSON200 == nil {
+			return nil, fmt.Errorf("list projects: %w", newAPIError(resp.HTTPResponse, resp.Body, restAPIHint, g.secrets()...))
+		}
+
+		for _, p := range resp.JSON200.Projects {
+			if _, ok := seen[p.Id]; ok || p.Id == "" {
+				continue
+			}
+			seen[p.Id] = struct{}{}
+
+			m := projectMeta{ID: p.Id, Name: p.Name}
+			if p.Description != nil {
+				m.Description = *p.Description
+			}
+			out = append(out, m)
+		}
+
+		if !resp.JSON200.HasMore {
+			break
+		}
+		offset, err = nextPageOffset(offset, resp.JSON200.NextOffset, len(resp.JSON200.Projects))
+		if err != nil {
+			return nil, fmt.Errorf("list projects: %w", err)
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Name != out[j].Name {
+			return out[i].Name < out[j].Name
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out, nil
+}
+
+// loadProjects returns the projects to generate from, resolving growthbook.projectNames to IDs. All projects are
+// listed when generator.emitProjects, growthbook.projectNames or generator.split=project is set. The config is left
+// untouched, so every run resolves the names again.
+func (g *Generator) loadProjects(ctx context.Context) (projectScope, error) {
+	gb := g.config.GrowthBook
+	needed := len(gb.ProjectNames) > 0 || g.config.Generator.EmitProjects || g.config.Generator.Split == config.SplitProject
+	if g.config.Generator.SourceMode() != config.SourceAPI || !needed {
+		return projectScope{IDs: gb.Projects()}, nil
+	}
+
+	projects, err := g.fetchProjects(ctx)
+	if err != nil {
+		return projectScope{}, err
+	}
+	if len(gb.ProjectNames) > 0 {
+		ids, err := resolveProjectNames(gb.ProjectNames, projects)
+		if err != nil {
+			return projectScope{}, err
+		}
+		gb.ProjectIDs = append(slices.Clip(gb.ProjectIDs), ids...)
+	}
+	return projectScope{IDs: gb.Projects(), All: projects}, nil
+}
+
+// resolveProjectNames maps project names to IDs. Names must match exactly and belong to a single project.
+func resolveProjectNames(names []string, projects []projectMeta) ([]string, error) {
+	out := make([]string, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		var matches []string
+		for _, p := range projects {
+			if p.Name == name {
+				matches = append(matches, p.ID)
+			}
+		}
+		switch len(matches) {
+		case 0:
+			return nil, fmt.Errorf("growthbook.projectNames: no project is named %q", name)
+		case 1:
+			out = append(out, matches[0])
+		default:
+			return nil, fmt.Errorf("growthbook.projectNames: projects %s are all named %q; use growthbook.projectIDs", strings.Join(matches, ", "), name)
+		}
+	}
+	return out, nil
+}
+
+// renderProjects declares a ProjectID constant per project, named after the project. When projects are configured,
+// only those are declared.
+func renderProjects(projects []projectMeta, configured []string) declSection {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// ProjectID is the ID of a GrowthBook project.\n")
+	fmt.Fprintf(&b, "type ProjectID string\n")
+
+	var out []projectMeta
+	for _, p := range projects {
+		if len(configured) == 0 || slices.Contains(configured, p.ID) {
+			out = append(out, p)
+		}
+	}
+	if len(out) == 0 {
+		return declSection{Body: b.Bytes()}
+	}
+
+	// Reserve the type name so a project called "ID" cannot redeclare it.
+	nameCounts := map[string]int{"ProjectID": 1}
+	fmt.Fprintf(&b, "\nconst (\n")
+	for _, p := range out {
+		label := p.Name
+		if strings.TrimSpace(label) == "" {
+			label = p.ID
+		}
+		name := "Project" + toExportedIdentifier(label)
+		nameCounts[name]++
+		if n := nameCounts[name]; n > 1 {
+			name = fmt.Sprintf("%s_%d", name, n)
+		}
+
+		fmt.Fprintf(&b, "\t// %s is the project %q.\n", name, p.Name)
+		if desc := strings.TrimSpace(p.Description); desc != "" {
+			fmt.Fprintf(&b, "\t//\n")
+			for _, line := range strings.Split(desc, "\n") {
+				if line = strings.TrimSpace(line); line != "" {
+					fmt.Fprintf(&b, "\t// %s\n", line)
+				}
+			}
+		}
+		fmt.Fprintf(&b, "\t%s ProjectID = %q\n", name, p.ID)
+	}
+	fmt.Fprintf(&b, ")\n")
+	return declSection{Body: b.Bytes()}
+}
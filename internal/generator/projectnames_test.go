@@ -0,0 +1,89 @@
+package generator
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/eastnine90/gbgen/internal/config"
+	"github.com/eastnine90/gbgen/internal/growthbookapi"
+)
+
+func TestGeneratorGenerate_ProjectNamesAndConstants(t *testing.T) {
+	ptr := func(s string) *string { return &s }
+	projects := &growthbookapi.ListProjectsResponse{}
+	projects.JSON200 = &struct {
+		Count      int                     `json:"count"`
+		HasMore    bool                    `json:"hasMore"`
+		Limit      int                     `json:"limit"`
+		NextOffset *int                    `json:"nextOffset"`
+		Offset     int                     `json:"offset"`
+		Projects   []growthbookapi.Project `json:"projects"`
+		Total      int                     `json:"total"`
+	}{
+		Projects: []growthbookapi.Project{
+			{Id: "prj_web", Name: "Web app", Description: ptr("Customer-facing web app.")},
+			{Id: "prj_api", Name: "Public API"},
+			{Id: "prj_other", Name: "Other"},
+			{Id: "prj_dup1", Name: "Dup"},
+			{Id: "prj_dup2", Name: "Dup"},
+		},
+	}
+	byProject := map[string]map[int32]*growthbookapi.ListFeaturesResponse{
+		"prj_web": {0: listFeaturesResponse([]growthbookapi.Feature{{Id: "web-flag", ValueType: growthbookapi.FeatureValueTypeBoolean}}, nil)},
+		"prj_api": {0: listFeaturesResponse([]growthbookapi.Feature{{Id: "api-flag", ValueType: growthbookapi.FeatureValueTypeBoolean}}, nil)},
+	}
+
+	cfg := config.Config{
+		GrowthBook: config.GrowthBookConfig{ProjectIDs: []string{"prj_web"}, ProjectNames: []string{"Public API"}},
+		Generator:  config.GeneratorConfig{PackageName: "features", EmitProjects: true, EmitManifest: true},
+	}
+	mock := &mockFeaturesAPI{t: t, featuresRespByProject: byProject, projectsResp: projects}
+	g := &Generator{api: mock, config: cfg}
+	src, err := g.Generate(context.Background())
+	if err != nil {
+		t.Fatalf("Generate error: %v", err)
+	}
+	// Resolving the names leaves the config, and so the manifest's config hash, unchanged for the next run.
+	if !reflect.DeepEqual(g.config, cfg) {
+		t.Fatalf("Generate changed the config:\n%+v\nwant:\n%+v", g.config, cfg)
+	}
+	again, err := g.Generate(context.Background())
+	if err != nil {
+		t.Fatalf("second Generate error: %v", err)
+	}
+	if string(again) != string(src) {
+		t.Fatalf("second Generate differs:\n%s\nfirst:\n%s", again, src)
+	}
+	assertGofmtIdempotent(t, src)
+	out := string(src)
+
+	assertContains(t, out, "FeatureWebFlag FeatureKey = \"web-flag\"")
+	assertContains(t, out, "FeatureApiFlag FeatureKey = \"api-flag\"")
+	assertContains(t, out, "type ProjectID string")
+	assertContains(t, out, "\t// ProjectWebApp is the project \"Web app\".\n\t//\n\t// Customer-facing web app.\n\tProjectWebApp ProjectID = \"prj_web\"\n")
+	assertContains(t, out, "ProjectPublicAPI ProjectID = \"prj_api\"")
+	assertNotContains(t, out, "prj_other")
+
+	for name, want := range map[string]string{
+		"Nope": `growthbook.projectNames: no project is named "Nope"`,
+		"Dup":  `growthbook.projectNames: projects prj_dup1, prj_dup2 are all named "Dup"`,
+	} {
+		cfg.GrowthBook = config.GrowthBookConfig{ProjectNames: []string{name}}
+		mock = &mockFeaturesAPI{t: t, projectsResp: projects}
+		_, err = (&Generator{api: mock, config: cfg}).Generate(context.Background())
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("project name %q: expected %q, got %v", name, want, err)
+		}
+	}
+}
+
+func TestRenderProjects_ReservesTypeName(t *testing.T) {
+	src, err := renderFeatureKeysGo("features", nil, false, renderProjects([]projectMeta{{ID: "prj_id", Name: "ID"}}, nil))
+	if err != nil {
+		t.Fatalf("render error: %v", err)
+	}
+	assertUniqueDecls(t, src)
+	assertContains(t, string(src), "\tProjectID_2 ProjectID = \"prj_id\"\n")
+}
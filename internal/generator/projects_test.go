@@ -0,0 +1,91 @@
+package generator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/eastnine90/gbgen/internal/config"
+	"github.com/eastnine90/gbgen/internal/growthbookapi"
+)
+
+func TestGeneratorGenerate_MultipleProjects(t *testing.T) {
+	feature := func(id, desc string) growthbookapi.Feature {
+		return growthbookapi.Feature{
+			Id:           id,
+			Description:  desc,
+			Environments: map[string]growthbookapi.FeatureEnvironment{"production": {Enabled: true}},
+			ValueType:    growthbookapi.FeatureValueTypeBoolean,
+		}
+	}
+	byProject := map[string]map[int32]*growthbookapi.ListFeaturesResponse{
+		"prj_web": {0: listFeaturesResponse([]growthbookapi.Feature{feature("shared-flag", "from web"), feature("web-only", "")}, nil)},
+		"prj_api": {0: listFeaturesResponse([]growthbookapi.Feature{feature("api-only", "API flag"), feature("shared-flag", "from api")}, nil)},
+	}
+
+	cfg := config.Config{
+		GrowthBook: config.GrowthBookConfig{ProjectIDs: []string{"prj_web", "prj_api"}},
+		Generator:  config.GeneratorConfig{PackageName: "features", OnDuplicateFeature: config.DuplicateFirst},
+	}
+	mock := &mockFeaturesAPI{t: t, featuresRespByProject: byProject}
+	src, err := (&Generator{api: mock, config: cfg}).Generate(context.Background())
+	if err != nil {
+		t.Fatalf("Generate error: %v", err)
+	}
+	assertGofmtIdempotent(t, src)
+	out := string(src)
+
+	assertContains(t, out, "\t// API flag\n\t//\n\t// Project: prj_api\n\tFeatureApiOnly FeatureKey = \"api-only\"")
+	assertContains(t, out, "\t// Project: prj_web\n\tFeatureWebOnly FeatureKey = \"web-only\"")
+	assertContains(t, out, "\t// from web\n\t//\n\t// Project: prj_web\n\tFeatureSharedFlag FeatureKey = \"shared-flag\"")
+	assertNotContains(t, out, "from api")
+	assertNotContains(t, out, "FeatureSharedFlag_2")
+	if len(mock.listCalls) != 2 {
+		t.Fatalf("expected 2 list calls, got %d", len(mock.listCalls))
+	}
+
+	// Reordering the projects changes which definition wins, not the identifiers.
+	cfg.GrowthBook.ProjectIDs = []string{"prj_api", "prj_web"}
+	mock = &mockFeaturesAPI{t: t, featuresRespByProject: byProject}
+	src, err = (&Generator{api: mock, config: cfg}).Generate(context.Background())
+	if err != nil {
+		t.Fatalf("Generate error: %v", err)
+	}
+	assertContains(t, string(src), "\t// from api\n\t//\n\t// Project: prj_api\n\tFeatureSharedFlag FeatureKey = \"shared-flag\"")
+
+	cfg.Generator.OnDuplicateFeature = config.DuplicateError
+	mock = &mockFeaturesAPI{t: t, featuresRespByProject: byProject}
+	_, err = (&Generator{api: mock, config: cfg}).Generate(context.Background())
+	if err == nil || !strings.Contains(err.Error(), `feature "shared-flag" is defined in projects "prj_api" and "prj_web"`) {
+		t.Fatalf("expected duplicate feature error, got %v", err)
+	}
+}
+
+func TestGeneratorGenerate_ProjectDocLine(t *testing.T) {
+	enabled := map[string]growthbookapi.FeatureEnvironment{"production": {Enabled: true}}
+	features := []growthbookapi.Feature{
+		{Id: "web-flag", Description: "Web flag", ValueType: growthbookapi.FeatureValueTypeBoolean, Project: "prj_web", Environments: enabled},
+		{Id: "global-flag", ValueType: growthbookapi.FeatureValueTypeBoolean, Environments: enabled},
+	}
+
+	for _, projectIDs := range [][]string{nil, {"prj_web"}} {
+		mock := &mockFeaturesAPI{t: t}
+		if projectIDs == nil {
+			mock.featuresRespByOffset = map[int32]*growthbookapi.ListFeaturesResponse{0: listFeaturesResponse(features, nil)}
+		} else {
+			mock.featuresRespByProject = map[string]map[int32]*growthbookapi.ListFeaturesResponse{"prj_web": {0: listFeaturesResponse(features[:1], nil)}}
+		}
+		cfg := config.Config{
+			GrowthBook: config.GrowthBookConfig{ProjectIDs: projectIDs},
+			Generator:  config.GeneratorConfig{PackageName: "features"},
+		}
+		src, err := (&Generator{api: mock, config: cfg}).Generate(context.Background())
+		if err != nil {
+			t.Fatalf("Generate (projects=%v) error: %v", projectIDs, err)
+		}
+		assertContains(t, string(src), "\t// Web flag\n\t//\n\t// Project: prj_web\n\tFeatureWebFlag FeatureKey = \"web-flag\"")
+		if projectIDs == nil {
+			assertContains(t, string(src), "const (\n\tFeatureGlobalFlag FeatureKey = \"global-flag\"")
+		}
+	}
+}
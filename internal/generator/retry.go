@@ -0,0 +1,233 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eastnine90/gbgen/internal/config"
+	"github.com/eastnine90/gbgen/internal/growthbookapi"
+)
+
+// retryDoer wraps an HTTP doer with per-attempt timeouts and retries with exponential backoff.
+//
+// Only idempotent requests (GET/HEAD) are retried, on network errors, 429 and transient 5xx responses.
+// Retry-After and GrowthBook's rate-limit headers (RateLimit-Reset / X-RateLimit-Reset) take precedence over
+// the computed backoff, up to maxBackoff. When a response reports an exhausted rate limit, later requests wait for the reset.
+type retryDoer struct {
+	next           growthbookapi.HttpRequestDoer
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	timeout        time.Duration
+
+	// sleep and now are replaceable in tests.
+	sleep func(ctx context.Context, d time.Duration) error
+	now   func() time.Time
+
+	mu        sync.Mutex
+	notBefore time.Time
+}
+
+func newRetryDoer(next growthbookapi.HttpRequestDoer, retry config.RetryConfig, httpCfg config.HTTPConfig) *retryDoer {
+	return &retryDoer{
+		next:           next,
+		maxAttempts:    retry.MaxAttempts,
+		initialBackoff: retry.InitialBackoff.Std(),
+		maxBackoff:     retry.MaxBackoff.Std(),
+		timeout:        httpCfg.Timeout.Std(),
+		sleep:          sleepContext,
+		now:            time.Now,
+	}
+}
+
+func (d *retryDoer) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	retryable := req.Method == http.MethodGet || req.Method == http.MethodHead
+
+	for attempt := 1; ; attempt++ {
+		if err := d.waitRateLimit(ctx); err != nil {
+			return nil, err
+		}
+
+		resp, err := d.doOnce(req)
+		if resp != nil {
+			d.observeRateLimit(resp)
+		}
+
+		if !retryable || attempt >= d.maxAttempts || ctx.Err() != nil || !shouldRetry(resp, err) {
+			return resp, err
+		}
+
+		wait := d.backoff(attempt, resp)
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+		if err := d.sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// doOnce performs a single attempt. The body is read eagerly so the per-attempt timeout also covers it.
+func (d *retryDoer) doOnce(req *http.Request) (*http.Response, error) {
+	if d.timeout <= 0 {
+		return d.next.Do(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), d.timeout)
+	defer cancel()
+
+	resp, err := d.next.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+func shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// backoff returns how long to wait before the next attempt.
+func (d *retryDoer) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := d.serverWait(resp); ok {
+			return wait
+		}
+	}
+
+	wait := d.initialBackoff << (attempt - 1)
+	if wait <= 0 || (d.maxBackoff > 0 && wait > d.maxBackoff) {
+		wait = d.maxBackoff
+	}
+	if wait <= 0 {
+		return 0
+	}
+	// Equal jitter: wait somewhere in [wait/2, wait] so parallel CI jobs don't retry in lockstep.
+	half := wait / 2
+	return half + rand.N(half+1)
+}
+
+// serverWait reads Retry-After or the rate-limit reset headers from a response, capped at maxBackoff so a server
+// asking for hours (or a far-off HTTP date) cannot stall generation.
+func (d *retryDoer) serverWait(resp *http.Response) (time.Duration, bool) {
+	wait, ok := d.requestedWait(resp)
+	return d.capWait(wait), ok
+}
+
+// capWait limits a wait requested by the server to maxBackoff.
+func (d *retryDoer) capWait(wait time.Duration) time.Duration {
+	if d.maxBackoff > 0 {
+		return min(wait, d.maxBackoff)
+	}
+	return wait
+}
+
+// requestedWait reads Retry-After or, for 429 responses, the rate-limit reset headers.
+func (d *retryDoer) requestedWait(resp *http.Response) (time.Duration, bool) {
+	if v := strings.TrimSpace(resp.Header.Get("Retry-After")); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+			return time.Duration(secs) * time.Second, true
+		}
+		if at, err := http.ParseTime(v); err == nil {
+			return max(at.Sub(d.now()), 0), true
+		}
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return d.rateLimitReset(resp)
+	}
+	return 0, false
+}
+
+// rateLimitReset parses RateLimit-Reset (seconds until reset) or X-RateLimit-Reset
+// (seconds until reset, or a unix timestamp).
+func (d *retryDoer) rateLimitReset(resp *http.Response) (time.Duration, bool) {
+	for _, h := range []string{"RateLimit-Reset", "X-RateLimit-Reset"} {
+		v := strings.TrimSpace(resp.Header.Get(h))
+		if v == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 0 {
+			continue
+		}
+		// Values larger than a day are unix timestamps.
+		if n > 24*60*60 {
+			return max(time.Unix(n, 0).Sub(d.now()), 0), true
+		}
+		return time.Duration(n) * time.Second, true
+	}
+	return 0, false
+}
+
+// observeRateLimit records when the rate limit resets if the response reports no remaining requests. Like
+// serverWait, the wait is capped at maxBackoff.
+func (d *retryDoer) observeRateLimit(resp *http.Response) {
+	remaining := resp.Header.Get("RateLimit-Remaining")
+	if remaining == "" {
+		remaining = resp.Header.Get("X-RateLimit-Remaining")
+	}
+	if strings.TrimSpace(remaining) != "0" {
+		return
+	}
+	wait, ok := d.rateLimitReset(resp)
+	if !ok {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if at := d.now().Add(d.capWait(wait)); at.After(d.notBefore) {
+		d.notBefore = at
+	}
+}
+
+func (d *retryDoer) waitRateLimit(ctx context.Context) error {
+	d.mu.Lock()
+	wait := d.notBefore.Sub(d.now())
+	d.mu.Unlock()
+	if wait <= 0 {
+		return nil
+	}
+	return d.sleep(ctx, wait)
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
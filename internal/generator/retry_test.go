@@ -0,0 +1,232 @@
+package generator
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/eastnine90/gbgen/internal/config"
+	"github.com/eastnine90/gbgen/internal/growthbookapi"
+)
+
+func TestRetryDoer_RetriesTransientFailures(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch calls.Add(1) {
+		case 1:
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			_, _ = w.Write([]byte("ok"))
+		}
+	}))
+	defer srv.Close()
+
+	d := newTestRetryDoer(srv.Client(), 4)
+	d.maxBackoff = 10 * time.Second
+	var waits []time.Duration
+	d.sleep = func(ctx context.Context, wait time.Duration) error {
+		waits = append(waits, wait)
+		return nil
+	}
+
+	resp := doGet(t, d, srv.URL)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d", resp.StatusCode)
+	}
+	if body, _ := io.ReadAll(resp.Body); string(body) != "ok" {
+		t.Fatalf("body = %q", body)
+	}
+	if calls.Load() != 3 {
+		t.Fatalf("expected 3 calls, got %d", calls.Load())
+	}
+	if len(waits) != 2 || waits[0] != 7*time.Second {
+		t.Fatalf("unexpected waits %v (expected Retry-After to be honored first)", waits)
+	}
+	if waits[1] < 50*time.Millisecond || waits[1] > 200*time.Millisecond {
+		t.Fatalf("second wait %v outside jittered backoff range", waits[1])
+	}
+}
+
+func TestRetryDoer_CapsServerWaitAtMaxBackoff(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	for _, retryAfter := range []string{"86400", now.Add(48 * time.Hour).UTC().Format(http.TimeFormat)} {
+		var calls atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) == 1 {
+				w.Header().Set("Retry-After", retryAfter)
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		}))
+
+		d := newTestRetryDoer(srv.Client(), 2)
+		d.now = func() time.Time { return now }
+		var waits []time.Duration
+		d.sleep = func(ctx context.Context, wait time.Duration) error {
+			waits = append(waits, wait)
+			return nil
+		}
+
+		if resp := doGet(t, d, srv.URL); resp.StatusCode != http.StatusOK {
+			t.Fatalf("Retry-After %q: status = %d", retryAfter, resp.StatusCode)
+		}
+		if len(waits) != 1 || waits[0] != time.Second {
+			t.Fatalf("Retry-After %q: waits = %v, want [1s] (maxBackoff)", retryAfter, waits)
+		}
+		srv.Close()
+	}
+}
+
+func TestRetryDoer_GivesUpAfterMaxAttempts(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	d := newTestRetryDoer(srv.Client(), 3)
+	d.sleep = func(context.Context, time.Duration) error { return nil }
+
+	resp := doGet(t, d, srv.URL)
+	if resp.StatusCode != http.StatusBadGateway {
+		t.Fatalf("status = %d", resp.StatusCode)
+	}
+	if calls.Load() != 3 {
+		t.Fatalf("expected 3 calls, got %d", calls.Load())
+	}
+}
+
+func TestRetryDoer_WaitsForRateLimitReset(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("RateLimit-Remaining", "0")
+		w.Header().Set("RateLimit-Reset", "12")
+	}))
+	defer srv.Close()
+
+	now := time.Unix(1_700_000_000, 0)
+	d := newTestRetryDoer(srv.Client(), 1)
+	d.maxBackoff = time.Minute
+	d.now = func() time.Time { return now }
+	var waits []time.Duration
+	d.sleep = func(ctx context.Context, wait time.Duration) error {
+		waits = append(waits, wait)
+		return nil
+	}
+
+	doGet(t, d, srv.URL)
+	doGet(t, d, srv.URL)
+	if len(waits) != 1 || waits[0] != 12*time.Second {
+		t.Fatalf("expected one 12s wait before the second request, got %v", waits)
+	}
+}
+
+func TestRetryDoer_CapsRateLimitResetAtMaxBackoff(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(now.Add(6*time.Hour).Unix(), 10))
+	}))
+	defer srv.Close()
+
+	d := newTestRetryDoer(srv.Client(), 1)
+	d.now = func() time.Time { return now }
+	var waits []time.Duration
+	d.sleep = func(ctx context.Context, wait time.Duration) error {
+		waits = append(waits, wait)
+		return nil
+	}
+
+	if resp := doGet(t, d, srv.URL); resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d", resp.StatusCode)
+	}
+	doGet(t, d, srv.URL)
+	if len(waits) != 1 || waits[0] != time.Second {
+		t.Fatalf("waits = %v, want [1s] (maxBackoff) before the second request", waits)
+	}
+}
+
+func TestRetryDoer_PerAttemptTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+	}))
+	defer srv.Close()
+
+	d := newTestRetryDoer(srv.Client(), 1)
+	d.timeout = 20 * time.Millisecond
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	if _, err := d.Do(req); err == nil || !strings.Contains(err.Error(), "deadline exceeded") {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestGeneratorGenerate_PaginationWithoutNextOffset(t *testing.T) {
+	feature := func(id string) growthbookapi.Feature {
+		return growthbookapi.Feature{Id: id, Environments: map[string]growthbookapi.FeatureEnvironment{"production": {Enabled: true}}, ValueType: growthbookapi.FeatureValueTypeBoolean}
+	}
+	page1 := listFeaturesResponse([]growthbookapi.Feature{feature("a"), feature("b")}, nil)
+	page1.JSON200.HasMore = true
+	page2 := listFeaturesResponse([]growthbookapi.Feature{feature("c")}, nil)
+
+	mock := &mockFeaturesAPI{
+		t: t,
+		featuresRespByOffset: map[int32]*growthbookapi.ListFeaturesResponse{
+			0: page1,
+			2: page2,
+		},
+	}
+	cfg := config.Config{Generator: config.GeneratorConfig{PackageName: "features"}}
+
+	src, err := (&Generator{api: mock, config: cfg}).Generate(context.Background())
+	if err != nil {
+		t.Fatalf("Generate error: %v", err)
+	}
+	assertContains(t, string(src), "\"c\"")
+
+	// An empty page that still claims hasMore must not loop forever.
+	stuck := listFeaturesResponse(nil, nil)
+	stuck.JSON200.HasMore = true
+	mock = &mockFeaturesAPI{
+		t:                    t,
+		featuresRespByOffset: map[int32]*growthbookapi.ListFeaturesResponse{0: stuck},
+	}
+	if _, err := (&Generator{api: mock, config: cfg}).Generate(context.Background()); err == nil {
+		t.Fatal("expected pagination error, got nil")
+	}
+}
+
+func newTestRetryDoer(next growthbookapi.HttpRequestDoer, maxAttempts int) *retryDoer {
+	return newRetryDoer(next,
+		config.RetryConfig{
+			MaxAttempts:    maxAttempts,
+			InitialBackoff: config.Duration(100 * time.Millisecond),
+			MaxBackoff:     config.Duration(time.Second),
+		},
+		config.HTTPConfig{Timeout: config.Duration(5 * time.Second)},
+	)
+}
+
+func doGet(t *testing.T, d *retryDoer, url string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := d.Do(req)
+	if err != nil {
+		t.Fatalf("Do error: %v", err)
+	}
+	return resp
+}
@@ -0,0 +1,132 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/eastnine90/gbgen/internal/growthbookapi"
+)
+
+type savedGroupMeta struct {
+	ID          string
+	Name        string
+	Description string
+	Projects    []string
+}
+
+// fetchSavedGroups lists all saved groups. When projects are configured, groups limited to other projects are left out.
+func (g *Generator) fetchSavedGroups(ctx context.Context, projects []string) ([]savedGroupMeta, error) {
+	pageSize := g.config.GrowthBook.PageSize
+	if pageSize <= 0 || pageSize > maxPageSize {
+		pageSize = defaultPageSize
+	}
+
+	seen := map[string]struct{}{}
+	var out []savedGroupMeta
+	for offset := 0; ; {
+		resp, err := g.api.ListSavedGroupsWithResponse(ctx, &growthbookapi.ListSavedGroupsParams{
+			Limit:  &pageSize,
+			Offset: &offset,
+		})
+		if err != nil {
+			return nil, redactTransportError(err, g.secrets()...)
+		}
+		if resp == nil {
+			return nil, fmt.Errorf("list saved groups: empty response")
+		}
+		if resp.JSON200 == nil {
+			return nil, fmt.Errorf("list saved groups: %w", newAPIError(resp.HTTPResponse, resp.Body, restAPIHint, g.secrets()...))
+		}
+
+		for _, sg := range resp.JSON200.SavedGroups {
+			if _, ok := seen[sg.Id]; ok || sg.Id == "" {
+				continue
+			}
+			seen[sg.Id] = struct{}{}
+
+			m := savedGroupMeta{ID: sg.Id, Name: sg.Name}
+			if sg.Description != nil {
+				m.Description = *sg.Description
+			}
+			if sg.Projects != nil {
+				m.Projects = *sg.Projects
+			}
+			if !inAnyProject(m.Projects, projects) {
+				continue
+			}
+			out = append(out, m)
+		}
+
+		if !resp.JSON200.HasMore {
+			break
+		}
+		offset, err = nextPageOffset(offset, resp.JSON200.NextOffset, len(resp.JSON200.SavedGroups))
+		if err != nil {
+			return nil, fmt.Errorf("list saved groups: %w", err)
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Name != out[j].Name {
+			return out[i].Name < out[j].Name
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out, nil
+}
+
+// inAnyProject reports whether a resource limited to the given projects (none: all projects) is visible
+// from any of the configured projects (none: all projects).
+func inAnyProject(resourceProjects, configured []string) bool {
+	if len(resourceProjects) == 0 || len(configured) == 0 {
+		return true
+	}
+	for _, p := range resourceProjects {
+		if slices.Contains(configured, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// renderSavedGroups declares a SavedGroupID constant per saved group, named after the group.
+func renderSavedGroups(groups []savedGroupMeta) declSection {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// SavedGroupID is the ID of a GrowthBook saved group, as referenced by targeting conditions.\n")
+	fmt.Fprintf(&b, "type SavedGroupID string\n\n")
+	if len(groups) == 0 {
+		return declSection{Body: b.Bytes()}
+	}
+
+	// Reserve the type name so a group called "ID" cannot redeclare it.
+	nameCounts := map[string]int{"SavedGroupID": 1}
+	fmt.Fprintf(&b, "const (\n")
+	for _, sg := range groups {
+		label := sg.Name
+		if strings.TrimSpace(label) == "" {
+			label = sg.ID
+		}
+		name := "SavedGroup" + toExportedIdentifier(label)
+		nameCounts[name]++
+		if n := nameCounts[name]; n > 1 {
+			name = fmt.Sprintf("%s_%d", name, n)
+		}
+
+		fmt.Fprintf(&b, "\t// %s is the saved group %q.\n", name, sg.Name)
+		if desc := strings.TrimSpace(sg.Description); desc != "" {
+			fmt.Fprintf(&b, "\t//\n")
+			for _, line := range strings.Split(desc, "\n") {
+				if line = strings.TrimSpace(line); line != "" {
+					fmt.Fprintf(&b, "\t// %s\n", line)
+				}
+			}
+		}
+		fmt.Fprintf(&b, "\t%s SavedGroupID = %q\n", name, sg.ID)
+	}
+	fmt.Fprintf(&b, ")\n")
+	return declSection{Body: b.Bytes()}
+}
@@ -0,0 +1,72 @@
+package generator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eastnine90/gbgen/internal/config"
+	"github.com/eastnine90/gbgen/internal/growthbookapi"
+)
+
+func TestGeneratorGenerate_SavedGroups(t *testing.T) {
+	ptr := func(s string) *string { return &s }
+	groups := &growthbookapi.ListSavedGroupsResponse{}
+	groups.JSON200 = &struct {
+		Count       int                        `json:"count"`
+		HasMore     bool                       `json:"hasMore"`
+		Limit       int                        `json:"limit"`
+		NextOffset  *int                       `json:"nextOffset"`
+		Offset      int                        `json:"offset"`
+		SavedGroups []growthbookapi.SavedGroup `json:"savedGroups"`
+		Total       int                        `json:"total"`
+	}{
+		SavedGroups: []growthbookapi.SavedGroup{
+			{Id: "grp_abc", Name: "Beta testers", Description: ptr("Users who opted into beta builds.")},
+			{Id: "grp_int", Name: "internal-staff"},
+			{Id: "grp_other", Name: "Other project", Projects: &[]string{"prj_other"}},
+		},
+	}
+
+	for _, typed := range []bool{false, true} {
+		mock := &mockFeaturesAPI{
+			t: t,
+			featuresRespByOffset: map[int32]*growthbookapi.ListFeaturesResponse{
+				0: listFeaturesResponse([]growthbookapi.Feature{{
+					Id:           "checkout",
+					Environments: map[string]growthbookapi.FeatureEnvironment{"production": {Enabled: true}},
+					ValueType:    growthbookapi.FeatureValueTypeBoolean,
+				}}, nil),
+			},
+			savedGroupsResp: groups,
+		}
+		project := "prj_web"
+		cfg := config.Config{
+			GrowthBook: config.GrowthBookConfig{ProjectID: &project},
+			Generator:  config.GeneratorConfig{PackageName: "features", EmitTypedFeatures: typed, EmitSavedGroups: true},
+		}
+		src, err := (&Generator{api: mock, config: cfg}).Generate(context.Background())
+		if err != nil {
+			t.Fatalf("Generate (typed=%v) error: %v", typed, err)
+		}
+		assertGofmtIdempotent(t, src)
+		out := string(src)
+
+		assertContains(t, out, "type SavedGroupID string")
+		assertContains(t, out, "\t// SavedGroupBetaTesters is the saved group \"Beta testers\".\n\t//\n\t// Users who opted into beta builds.\n\tSavedGroupBetaTesters SavedGroupID = \"grp_abc\"\n")
+		assertContains(t, out, "SavedGroupInternalStaff SavedGroupID = \"grp_int\"")
+		assertNotContains(t, out, "grp_other")
+	}
+}
+
+func TestRenderSavedGroups_ReservesTypeName(t *testing.T) {
+	src, err := renderFeatureKeysGo("features", nil, false, renderSavedGroups([]savedGroupMeta{
+		{ID: "grp_id", Name: "ID"},
+		{ID: "grp_id2", Name: "id"},
+	}))
+	if err != nil {
+		t.Fatalf("render error: %v", err)
+	}
+	assertUniqueDecls(t, src)
+	assertContains(t, string(src), "\tSavedGroupID_2 SavedGroupID = \"grp_id\"\n")
+	assertContains(t, string(src), "\tSavedGroupId SavedGroupID = \"grp_id2\"\n")
+}
@@ -0,0 +1,71 @@
+package generator
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// errDecryptionKey is returned when an encrypted SDK payload cannot be decrypted with the configured key.
+var errDecryptionKey = errors.New("cannot decrypt sdk payload: check growthbook.decryptionKey")
+
+// decryptSDKFeatures decrypts an `encryptedFeatures` blob the same way the GrowthBook SDKs do:
+// "<base64 iv>.<base64 ciphertext>", AES-CBC with PKCS#7 padding and a base64-encoded key.
+func decryptSDKFeatures(encrypted string, decryptionKey string) (map[string]sdkFeature, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(decryptionKey))
+	if err != nil {
+		return nil, fmt.Errorf("%w (key is not valid base64)", errDecryptionKey)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("%w (%d-byte key is not a valid AES key)", errDecryptionKey, len(key))
+	}
+
+	ivPart, cipherPart, ok := strings.Cut(encrypted, ".")
+	if !ok {
+		return nil, errors.New("decrypt sdk payload: encryptedFeatures is not in <iv>.<ciphertext> format")
+	}
+	iv, err := base64.StdEncoding.DecodeString(ivPart)
+	if err != nil || len(iv) != block.BlockSize() {
+		return nil, errors.New("decrypt sdk payload: invalid initialization vector")
+	}
+	cipherText, err := base64.StdEncoding.DecodeString(cipherPart)
+	if err != nil || len(cipherText) == 0 || len(cipherText)%block.BlockSize() != 0 {
+		return nil, errors.New("decrypt sdk payload: invalid ciphertext")
+	}
+
+	plain := make([]byte, len(cipherText))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plain, cipherText)
+
+	plain, ok = unpadPKCS7(plain, block.BlockSize())
+	if !ok {
+		return nil, errDecryptionKey
+	}
+
+	// A wrong key occasionally yields valid padding; garbage JSON is reported the same way.
+	var features map[string]sdkFeature
+	if err := json.Unmarshal(plain, &features); err != nil {
+		return nil, errDecryptionKey
+	}
+	return features, nil
+}
+
+func unpadPKCS7(b []byte, blockSize int) ([]byte, bool) {
+	if len(b) == 0 {
+		return nil, false
+	}
+	n := int(b[len(b)-1])
+	if n == 0 || n > blockSize || n > len(b) {
+		return nil, false
+	}
+	for _, p := range b[len(b)-n:] {
+		if int(p) != n {
+			return nil, false
+		}
+	}
+	return b[:len(b)-n], true
+}
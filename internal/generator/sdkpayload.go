@@ -0,0 +1,135 @@
+package generator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/eastnine90/gbgen/internal/config"
+	"github.com/eastnine90/gbgen/internal/growthbookapi"
+)
+
+// sdkPayload is the subset of the public SDK payload (/api/features/{clientKey}) used by gbgen.
+type sdkPayload struct {
+	Features          map[string]sdkFeature `json:"features"`
+	EncryptedFeatures string                `json:"encryptedFeatures"`
+}
+
+type sdkFeature struct {
+	DefaultValue any              `json:"defaultValue"`
+	Rules        []sdkFeatureRule `json:"rules"`
+}
+
+type sdkFeatureRule struct {
+	Force      any   `json:"force"`
+	Variations []any `json:"variations"`
+}
+
+// fetchSDKFeatureMeta reads features from the SDK connection payload served by GrowthBook or GrowthBook Proxy.
+//
+// Encrypted payloads are decrypted with growthbook.decryptionKey.
+// The payload carries no descriptions, owners or environment state, so features are rendered without
+// doc comments and are never marked as having no active environments. Value types are inferred from values.
+func (g *Generator) fetchSDKFeatureMeta(ctx context.Context) ([]featureMeta, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, sdkPayloadURL(g.config.GrowthBook), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := g.httpDoer().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch sdk payload: %w", redactTransportError(err, g.secrets()...))
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("fetch sdk payload: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch sdk payload: %w", newAPIError(resp, body, sdkPayloadHint, g.secrets()...))
+	}
+
+	var payload sdkPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("parse sdk payload: %w", err)
+	}
+
+	features := payload.Features
+	if payload.EncryptedFeatures != "" {
+		if g.config.GrowthBook.DecryptionKey == "" {
+			return nil, fmt.Errorf("sdk payload is encrypted: set growthbook.decryptionKey")
+		}
+		features, err = decryptSDKFeatures(payload.EncryptedFeatures, g.config.GrowthBook.DecryptionKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return sdkPayloadFeatureMeta(features), nil
+}
+
+func sdkPayloadFeatureMeta(features map[string]sdkFeature) []featureMeta {
+	out := make([]featureMeta, 0, len(features))
+	for id, f := range features {
+		if id == "" {
+			continue
+		}
+		out = append(out, featureMeta{
+			ID:        id,
+			ValueType: inferSDKValueType(f),
+		})
+	}
+	sortFeatureMeta(out)
+	return out
+}
+
+// sdkPayloadURL builds the SDK payload endpoint from the configured API base URL.
+// A trailing REST API path (growthbook.apiPath, "/api/v1" by default) is dropped so the same apiBaseURL works for
+// both sources.
+func sdkPayloadURL(gb config.GrowthBookConfig) string {
+	base := strings.TrimRight(gb.APIBaseURL, "/")
+	if apiPath := restAPIPath(gb); apiPath != "/" {
+		base = strings.TrimSuffix(base, apiPath)
+	}
+	return base + "/api/features/" + url.PathEscape(gb.ClientKey)
+}
+
+// inferSDKValueType derives a feature's value type from its default value, falling back to rule values.
+// Features whose values are all null are treated as JSON.
+func inferSDKValueType(f sdkFeature) growthbookapi.FeatureValueType {
+	if vt, ok := valueTypeOf(f.DefaultValue); ok {
+		return vt
+	}
+	for _, r := range f.Rules {
+		if vt, ok := valueTypeOf(r.Force); ok {
+			return vt
+		}
+		for _, v := range r.Variations {
+			if vt, ok := valueTypeOf(v); ok {
+				return vt
+			}
+		}
+	}
+	return growthbookapi.FeatureValueTypeJson
+}
+
+func valueTypeOf(v any) (growthbookapi.FeatureValueType, bool) {
+	switch v.(type) {
+	case bool:
+		return growthbookapi.FeatureValueTypeBoolean, true
+	case string:
+		return growthbookapi.FeatureValueTypeString, true
+	case float64:
+		return growthbookapi.FeatureValueTypeNumber, true
+	case map[string]any, []any:
+		return growthbookapi.FeatureValueTypeJson, true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,128 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/eastnine90/gbgen/internal/config"
+)
+
+const testSDKPayload = `{
+  "status": 200,
+  "features": {
+    "checkout-redesign": {"defaultValue": false, "rules": [{"force": true}]},
+    "theme-name": {"defaultValue": "light"},
+    "max-items": {"defaultValue": 10},
+    "checkout-config": {"defaultValue": {"currency": "USD"}},
+    "late-typed": {"defaultValue": null, "rules": [{"variations": ["a", "b"]}]}
+  },
+  "dateUpdated": "2024-01-01T00:00:00.000Z"
+}`
+
+func TestGeneratorGenerate_SDKPayload(t *testing.T) {
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			t.Errorf("unexpected Authorization header %q", auth)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(testSDKPayload))
+	}))
+	defer srv.Close()
+
+	cfg := config.Config{
+		GrowthBook: config.GrowthBookConfig{
+			APIBaseURL: srv.URL + "/api/v1",
+			ClientKey:  "sdk-abc123",
+		},
+		Generator: config.GeneratorConfig{
+			PackageName:       "features",
+			EmitTypedFeatures: true,
+			Source:            config.SourceSDK,
+		},
+	}
+
+	g := &Generator{httpClient: srv.Client(), config: cfg}
+	src, err := g.Generate(context.Background())
+	if err != nil {
+		t.Fatalf("Generate error: %v", err)
+	}
+	assertGofmtIdempotent(t, src)
+	out := string(src)
+
+	if gotPath != "/api/features/sdk-abc123" {
+		t.Fatalf("unexpected request path %q", gotPath)
+	}
+	assertContains(t, out, "types.BooleanFeature(\"checkout-redesign\")")
+	assertContains(t, out, "types.StringFeature(\"theme-name\")")
+	assertContains(t, out, "types.NumberFeature(\"max-items\")")
+	assertContains(t, out, "types.JSONFeature(\"checkout-config\")")
+	assertContains(t, out, "types.StringFeature(\"late-typed\")")
+	assertNotContains(t, out, "Deprecated")
+}
+
+func TestGeneratorGenerate_SDKPayload_Encrypted(t *testing.T) {
+	key := bytes.Repeat([]byte{0x42}, 16)
+	encrypted := encryptTestSDKFeatures(t, key, `{"checkout-redesign":{"defaultValue":true}}`)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"status":200,"features":{},"encryptedFeatures":%q}`, encrypted)
+	}))
+	defer srv.Close()
+
+	cfg := config.Config{
+		GrowthBook: config.GrowthBookConfig{
+			APIBaseURL:    srv.URL,
+			ClientKey:     "sdk-abc123",
+			DecryptionKey: base64.StdEncoding.EncodeToString(key),
+		},
+		Generator: config.GeneratorConfig{
+			PackageName: "features",
+			Source:      config.SourceSDK,
+		},
+	}
+
+	g := &Generator{httpClient: srv.Client(), config: cfg}
+	src, err := g.Generate(context.Background())
+	if err != nil {
+		t.Fatalf("Generate error: %v", err)
+	}
+	assertContains(t, string(src), "FeatureCheckoutRedesign FeatureKey = \"checkout-redesign\"")
+
+	g.config.GrowthBook.DecryptionKey = base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{0x17}, 16))
+	if _, err := g.Generate(context.Background()); !errors.Is(err, errDecryptionKey) {
+		t.Fatalf("expected errDecryptionKey for wrong key, got %v", err)
+	}
+
+	g.config.GrowthBook.DecryptionKey = ""
+	if _, err := g.Generate(context.Background()); err == nil || !strings.Contains(err.Error(), "growthbook.decryptionKey") {
+		t.Fatalf("expected missing decryptionKey error, got %v", err)
+	}
+}
+
+func encryptTestSDKFeatures(t *testing.T, key []byte, plain string) string {
+	t.Helper()
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	iv := bytes.Repeat([]byte{0x01}, block.BlockSize())
+
+	pad := block.BlockSize() - len(plain)%block.BlockSize()
+	buf := append([]byte(plain), bytes.Repeat([]byte{byte(pad)}, pad)...)
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(buf, buf)
+
+	return base64.StdEncoding.EncodeToString(iv) + "." + base64.StdEncoding.EncodeToString(buf)
+}
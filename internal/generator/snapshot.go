@@ -0,0 +1,130 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/eastnine90/gbgen/internal/growthbookapi"
+)
+
+// loadSnapshotFeatureMeta reads feature definitions from a saved snapshot file.
+//
+// Accepted shapes (top-level JSON values may be concatenated, e.g. `curl ... >> snapshot.json` per page):
+//   - a `/api/v1/features` list response: {"features": [...], "hasMore": ...}
+//   - an export keyed by feature ID: {"features": {"<id>": {...}}}
+//   - a single feature object, or an array of any of the above
+//
+// Features that appear more than once (e.g. overlapping pages) are kept once, first occurrence wins.
+func loadSnapshotFeatureMeta(path string) ([]featureMeta, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read snapshot: %w", err)
+	}
+
+	features, err := parseSnapshot(b)
+	if err != nil {
+		return nil, fmt.Errorf("parse snapshot %s: %w", path, err)
+	}
+
+	out := uniqueFeatureMeta(features)
+	sortFeatureMeta(out)
+	return out, nil
+}
+
+func parseSnapshot(b []byte) ([]growthbookapi.Feature, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+
+	var out []growthbookapi.Feature
+	for {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		features, err := collectSnapshotFeatures(raw)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, features...)
+	}
+	if len(out) == 0 {
+		return nil, errors.New("no features found")
+	}
+	return out, nil
+}
+
+func collectSnapshotFeatures(raw json.RawMessage) ([]growthbookapi.Feature, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	switch raw[0] {
+	case '[':
+		var items []json.RawMessage
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return nil, err
+		}
+		var out []growthbookapi.Feature
+		for _, item := range items {
+			features, err := collectSnapshotFeatures(item)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, features...)
+		}
+		return out, nil
+
+	case '{':
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &obj); err != nil {
+			return nil, err
+		}
+
+		if inner, ok := obj["features"]; ok {
+			inner = bytes.TrimSpace(inner)
+			if len(inner) > 0 && inner[0] == '{' {
+				return collectFeaturesByID(inner)
+			}
+			return collectSnapshotFeatures(inner)
+		}
+
+		if _, ok := obj["id"]; ok {
+			var f growthbookapi.Feature
+			if err := json.Unmarshal(raw, &f); err != nil {
+				return nil, err
+			}
+			return []growthbookapi.Feature{f}, nil
+		}
+
+		return nil, errors.New(`unrecognized snapshot object (expected a feature or an object with "features")`)
+
+	case 'n':
+		// Tolerate `null` values (e.g. an empty page written by a script).
+		return nil, nil
+
+	default:
+		return nil, fmt.Errorf("unrecognized snapshot value starting with %q", raw[0])
+	}
+}
+
+func collectFeaturesByID(raw json.RawMessage) ([]growthbookapi.Feature, error) {
+	var byID map[string]growthbookapi.Feature
+	if err := json.Unmarshal(raw, &byID); err != nil {
+		return nil, err
+	}
+	out := make([]growthbookapi.Feature, 0, len(byID))
+	for id, f := range byID {
+		if f.Id == "" {
+			f.Id = id
+		}
+		out = append(out, f)
+	}
+	return out, nil
+}
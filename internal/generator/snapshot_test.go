@@ -0,0 +1,168 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/eastnine90/gbgen/internal/config"
+	"github.com/eastnine90/gbgen/internal/growthbookapi"
+)
+
+func TestGeneratorGenerate_SnapshotMatchesAPI(t *testing.T) {
+	page1 := []growthbookapi.Feature{
+		{
+			Id:          "checkout-redesign",
+			Description: "Checkout redesign flag",
+			Environments: map[string]growthbookapi.FeatureEnvironment{
+				"production": {Enabled: true},
+			},
+			ValueType: growthbookapi.FeatureValueTypeBoolean,
+		},
+		{
+			Id:          "theme-name",
+			Description: "Theme name",
+			Environments: map[string]growthbookapi.FeatureEnvironment{
+				"production": {Enabled: false},
+			},
+			ValueType: growthbookapi.FeatureValueTypeString,
+		},
+	}
+	page2 := []growthbookapi.Feature{
+		{
+			Id: "max-items",
+			Environments: map[string]growthbookapi.FeatureEnvironment{
+				"production": {Enabled: true},
+			},
+			ValueType: growthbookapi.FeatureValueTypeNumber,
+		},
+	}
+
+	next := 2
+	resp1 := listFeaturesResponse(page1, &next)
+	resp2 := listFeaturesResponse(page2, nil)
+
+	// Concatenated list responses, as produced by appending each page to one file.
+	var snapshot bytes.Buffer
+	for _, r := range []*growthbookapi.ListFeaturesResponse{resp1, resp2} {
+		b, err := json.Marshal(r.JSON200)
+		if err != nil {
+			t.Fatal(err)
+		}
+		snapshot.Write(b)
+		snapshot.WriteByte('\n')
+	}
+	snapshotPath := filepath.Join(t.TempDir(), "features.json")
+	if err := os.WriteFile(snapshotPath, snapshot.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, typed := range []bool{false, true} {
+		cfg := config.Config{
+			GrowthBook: config.GrowthBookConfig{APIBaseURL: "https://api.growthbook.io", APIKey: "secret_x"},
+			Generator: config.GeneratorConfig{
+				PackageName:       "features",
+				EmitTypedFeatures: typed,
+				EmitFeatureList:   true,
+			},
+		}
+
+		mock := &mockFeaturesAPI{
+			t: t,
+			featuresRespByOffset: map[int32]*growthbookapi.ListFeaturesResponse{
+				0: resp1,
+				2: resp2,
+			},
+		}
+		fromAPI, err := (&Generator{api: mock, config: cfg}).Generate(context.Background())
+		if err != nil {
+			t.Fatalf("Generate (api) error: %v", err)
+		}
+
+		cfg.Generator.FromFile = snapshotPath
+		fromFile, err := (&Generator{config: cfg}).Generate(context.Background())
+		if err != nil {
+			t.Fatalf("Generate (file) error: %v", err)
+		}
+
+		if !bytes.Equal(fromAPI, fromFile) {
+			t.Fatalf("typed=%v: snapshot output differs from API output\n--- api ---\n%s\n--- file ---\n%s", typed, fromAPI, fromFile)
+		}
+	}
+}
+
+func TestParseSnapshot_Shapes(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{
+			name: "list response",
+			in:   `{"features":[{"id":"a","valueType":"boolean"},{"id":"b","valueType":"string"}],"hasMore":false}`,
+			want: []string{"a", "b"},
+		},
+		{
+			name: "array of list responses",
+			in:   `[{"features":[{"id":"a","valueType":"boolean"}]},{"features":[{"id":"b","valueType":"number"}]}]`,
+			want: []string{"a", "b"},
+		},
+		{
+			name: "array of features",
+			in:   `[{"id":"a","valueType":"boolean"}]`,
+			want: []string{"a"},
+		},
+		{
+			name: "export keyed by id",
+			in:   `{"features":{"a":{"valueType":"json"}}}`,
+			want: []string{"a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			features, err := parseSnapshot([]byte(tt.in))
+			if err != nil {
+				t.Fatalf("parseSnapshot error: %v", err)
+			}
+			var got []string
+			for _, f := range features {
+				got = append(got, f.Id)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got ids %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("got ids %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+
+	if _, err := parseSnapshot([]byte(`{"unexpected":true}`)); err == nil {
+		t.Fatal("expected error for unrecognized snapshot shape")
+	}
+}
+
+func listFeaturesResponse(features []growthbookapi.Feature, nextOffset *int) *growthbookapi.ListFeaturesResponse {
+	resp := &growthbookapi.ListFeaturesResponse{}
+	resp.JSON200 = &struct {
+		Count      int                     `json:"count"`
+		Features   []growthbookapi.Feature `json:"features"`
+		HasMore    bool                    `json:"hasMore"`
+		Limit      int                     `json:"limit"`
+		NextOffset *int                    `json:"nextOffset"`
+		Offset     int                     `json:"offset"`
+		Total      int                     `json:"total"`
+	}{
+		Count:      len(features),
+		Features:   features,
+		HasMore:    nextOffset != nil,
+		NextOffset: nextOffset,
+	}
+	return resp
+}
@@ -0,0 +1,171 @@
+package generator
+
+import (
+	"fmt"
+	"go/build"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/eastnine90/gbgen/internal/config"
+)
+
+// OutputFile is a generated Go file. Name is relative to generator.outputDir.
+type OutputFile struct {
+	Name string
+	Src  []byte
+}
+
+// renderFiles renders the main file and, with generator.split, one file per project or tag.
+//
+// Identifiers are assigned across all features before they are split, so moving a feature to another file never
+// renames it. Shared declarations (FeatureKey, FeatureList, extra sections) and ungrouped features stay in the
+// main file, as does the manifest (generator.emitManifest).
+func (g *Generator) renderFiles(features []featureMeta, sections []declSection, projects []projectMeta, configHash string) ([]OutputFile, error) {
+	cfg := g.config.Generator
+	mainName := cfg.OutputFileName()
+
+	if cfg.Split == "" {
+		if cfg.EmitManifest {
+			manifest, err := renderManifest(newManifest(nameAndDedupe(features), nil, configHash))
+			if err != nil {
+				return nil, err
+			}
+			sections = append(slices.Clip(sections), manifest)
+		}
+		var src []byte
+		var err error
+		if cfg.EmitTypedFeatures {
+			src, err = renderTypedFeaturesGo(cfg.PackageName, features, cfg.EmitFeatureList, sections...)
+		} else {
+			src, err = renderFeatureKeysGo(cfg.PackageName, features, cfg.EmitFeatureList, sections...)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return []OutputFile{{Name: mainName, Src: src}}, nil
+	}
+
+	all := nameAndDedupe(features)
+	groups := map[string][]namedFeature{}
+	files := map[string]string{}
+	var own []namedFeature
+	for _, f := range all {
+		name := mainName
+		if slug := fileSlug(g.splitLabel(f.featureMeta, projects)); slug != "" {
+			name = splitFileName(mainName, slug)
+		}
+		files[f.ID] = name
+		if name == mainName {
+			own = append(own, f)
+			continue
+		}
+		groups[name] = append(groups[name], f)
+	}
+	if len(all) > 0 && len(groups) == 0 {
+		g.warnf("generator.split: no feature has a %s; all features are in %s", cfg.Split, mainName)
+	}
+	if cfg.EmitManifest {
+		manifest, err := renderManifest(newManifest(all, files, configHash))
+		if err != nil {
+			return nil, err
+		}
+		sections = append(slices.Clip(sections), manifest)
+	}
+
+	var mainSrc []byte
+	var err error
+	if cfg.EmitTypedFeatures {
+		mainSrc, err = renderTypedFeaturesFile(cfg.PackageName, own, all, cfg.EmitFeatureList, sections)
+	} else {
+		mainSrc, err = renderFeatureKeysFile(cfg.PackageName, own, all, cfg.EmitFeatureList, sections)
+	}
+	if err != nil {
+		return nil, err
+	}
+	out := []OutputFile{{Name: mainName, Src: mainSrc}}
+
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		var src []byte
+		if cfg.EmitTypedFeatures {
+			src, err = renderTypedFeaturesPart(cfg.PackageName, groups[name])
+		} else {
+			src, err = renderFeatureKeysPart(cfg.PackageName, groups[name])
+		}
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, OutputFile{Name: name, Src: src})
+	}
+	return out, nil
+}
+
+// splitLabel returns the project or tag a feature's file is named after, or "" for the main file.
+//
+// For generator.split=tag, the first of generator.includeTags the feature carries wins, then its first tag.
+// Projects are named after the project when the project list was loaded, and after the project ID otherwise.
+func (g *Generator) splitLabel(f featureMeta, projects []projectMeta) string {
+	switch g.config.Generator.Split {
+	case config.SplitProject:
+		for _, p := range projects {
+			if p.ID == f.Project && strings.TrimSpace(p.Name) != "" {
+				return p.Name
+			}
+		}
+		return f.Project
+	case config.SplitTag:
+		for _, tag := range g.config.Generator.IncludeTags {
+			if slices.Contains(f.Tags, tag) {
+				return tag
+			}
+		}
+		if len(f.Tags) > 0 {
+			return f.Tags[0]
+		}
+	}
+	return ""
+}
+
+// fileSlug lowercases s and replaces runs of other characters than letters and digits with "_".
+func fileSlug(s string) string {
+	return strings.ToLower(strings.Join(splitNonAlnum(s), "_"))
+}
+
+// splitFileName inserts the slug before the first dot of the main file name: features.gen.go becomes
+// features_payments.gen.go. Names the go tool would treat as test or platform-specific files (features_test.go,
+// features_linux.go) get a "_gbgen" suffix.
+func splitFileName(mainName, slug string) string {
+	base, ext, ok := strings.Cut(mainName, ".")
+	if !ok {
+		return fmt.Sprintf("%s_%s", mainName, slug)
+	}
+	name := fmt.Sprintf("%s_%s.%s", base, slug, ext)
+	if !isPlainGoFileName(name) {
+		name = fmt.Sprintf("%s_%s_gbgen.%s", base, slug, ext)
+	}
+	return name
+}
+
+// isPlainGoFileName reports whether the go tool builds a file with this name on every platform, outside of tests.
+func isPlainGoFileName(name string) bool {
+	if strings.HasSuffix(name, "_test.go") {
+		return false
+	}
+	// A context matching no real platform rejects exactly the names with a _GOOS or _GOARCH suffix.
+	ctxt := build.Context{
+		GOOS:     "none",
+		GOARCH:   "none",
+		Compiler: "gc",
+		OpenFile: func(string) (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader("package p\n")), nil
+		},
+	}
+	ok, err := ctxt.MatchFile(".", name)
+	return err == nil && ok
+}
@@ -0,0 +1,142 @@
+package generator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/eastnine90/gbgen/internal/config"
+	"github.com/eastnine90/gbgen/internal/growthbookapi"
+)
+
+func TestGeneratorGenerateFiles_SplitByTag(t *testing.T) {
+	features := []growthbookapi.Feature{
+		{Id: "checkout-v2", ValueType: growthbookapi.FeatureValueTypeBoolean, Tags: []string{"web", "payments"}},
+		{Id: "refund-limit", ValueType: growthbookapi.FeatureValueTypeNumber, Tags: []string{"payments"}},
+		{Id: "search", ValueType: growthbookapi.FeatureValueTypeString, Tags: []string{"Search Team"}},
+		{Id: "web-only", ValueType: growthbookapi.FeatureValueTypeBoolean, Tags: []string{"web"}},
+	}
+
+	for _, typed := range []bool{false, true} {
+		mock := &mockFeaturesAPI{
+			t:                    t,
+			featuresRespByOffset: map[int32]*growthbookapi.ListFeaturesResponse{0: listFeaturesResponse(features, nil)},
+		}
+		cfg := config.Config{Generator: config.GeneratorConfig{
+			PackageName:       "features",
+			EmitTypedFeatures: typed,
+			EmitFeatureList:   true,
+			IncludeTags:       []string{"payments", "Search Team", "web"},
+			Split:             config.SplitTag,
+		}}
+		g := &Generator{api: mock, config: cfg}
+		files, err := g.GenerateFiles(context.Background())
+		if err != nil {
+			t.Fatalf("GenerateFiles (typed=%v) error: %v", typed, err)
+		}
+
+		var names []string
+		byName := map[string]string{}
+		for _, f := range files {
+			assertGofmtIdempotent(t, f.Src)
+			names = append(names, f.Name)
+			byName[f.Name] = string(f.Src)
+		}
+		if got, want := strings.Join(names, ","), "features.gen.go,features_payments.gen.go,features_search_team.gen.go,features_web.gen.go"; got != want {
+			t.Fatalf("files (typed=%v) = %s, want %s", typed, got, want)
+		}
+
+		main := byName["features.gen.go"]
+		assertContains(t, main, "type FeatureKey string")
+		assertContains(t, main, "var FeatureList")
+		assertNotContains(t, main, "const (")
+		assertNotContains(t, main, "FeatureCheckoutV2 FeatureKey")
+		assertNotContains(t, main, "gbgen/types")
+
+		payments := byName["features_payments.gen.go"]
+		assertContains(t, payments, "// Code generated by gbgen")
+		assertContains(t, payments, "package features\n")
+		assertNotContains(t, payments, "type FeatureKey")
+		assertNotContains(t, payments, "FeatureList")
+		search := byName["features_search_team.gen.go"]
+		web := byName["features_web.gen.go"]
+		if typed {
+			assertContains(t, payments, "\t\"github.com/eastnine90/gbgen/types\"\n")
+			assertContains(t, payments, "FeatureCheckoutV2 = types.BooleanFeature(\"checkout-v2\")")
+			assertContains(t, payments, "FeatureRefundLimit = types.NumberFeature(\"refund-limit\")")
+			assertContains(t, search, "FeatureSearch = types.StringFeature(\"search\")")
+			assertContains(t, web, "FeatureWebOnly = types.BooleanFeature(\"web-only\")")
+		} else {
+			assertContains(t, payments, "FeatureCheckoutV2 FeatureKey = \"checkout-v2\"")
+			assertContains(t, payments, "FeatureRefundLimit FeatureKey = \"refund-limit\"")
+			assertContains(t, search, "FeatureSearch FeatureKey = \"search\"")
+			assertContains(t, web, "FeatureWebOnly FeatureKey = \"web-only\"")
+		}
+
+		if _, err := g.Generate(context.Background()); err == nil || !strings.Contains(err.Error(), `generator.split is "tag"`) {
+			t.Fatalf("Generate with split: err = %v", err)
+		}
+	}
+}
+
+func TestGeneratorGenerateFiles_SplitByProject(t *testing.T) {
+	projects := &growthbookapi.ListProjectsResponse{}
+	projects.JSON200 = &struct {
+		Count      int                     `json:"count"`
+		HasMore    bool                    `json:"hasMore"`
+		Limit      int                     `json:"limit"`
+		NextOffset *int                    `json:"nextOffset"`
+		Offset     int                     `json:"offset"`
+		Projects   []growthbookapi.Project `json:"projects"`
+		Total      int                     `json:"total"`
+	}{
+		Projects: []growthbookapi.Project{{Id: "prj_web", Name: "Web app"}},
+	}
+	features := []growthbookapi.Feature{
+		{Id: "web-flag", ValueType: growthbookapi.FeatureValueTypeBoolean, Project: "prj_web"},
+		{Id: "api-flag", ValueType: growthbookapi.FeatureValueTypeBoolean, Project: "prj_api"},
+		{Id: "global-flag", ValueType: growthbookapi.FeatureValueTypeBoolean},
+	}
+	mock := &mockFeaturesAPI{
+		t:                    t,
+		featuresRespByOffset: map[int32]*growthbookapi.ListFeaturesResponse{0: listFeaturesResponse(features, nil)},
+		projectsResp:         projects,
+	}
+	cfg := config.Config{Generator: config.GeneratorConfig{
+		PackageName: "features",
+		OutputFile:  "flags.go",
+		Split:       config.SplitProject,
+	}}
+	files, err := (&Generator{api: mock, config: cfg}).GenerateFiles(context.Background())
+	if err != nil {
+		t.Fatalf("GenerateFiles error: %v", err)
+	}
+
+	byName := map[string]string{}
+	for _, f := range files {
+		byName[f.Name] = string(f.Src)
+	}
+	if len(byName) != 3 {
+		t.Fatalf("files = %v, want flags.go, flags_prj_api.go and flags_web_app.go", files)
+	}
+	assertContains(t, byName["flags.go"], "FeatureGlobalFlag FeatureKey = \"global-flag\"")
+	assertContains(t, byName["flags_web_app.go"], "FeatureWebFlag FeatureKey = \"web-flag\"")
+	assertContains(t, byName["flags_prj_api.go"], "FeatureApiFlag FeatureKey = \"api-flag\"")
+}
+
+func TestSplitFileName(t *testing.T) {
+	for _, tc := range []struct {
+		main, label, want string
+	}{
+		{"features.gen.go", "payments", "features_payments.gen.go"},
+		{"features.gen.go", "Team: Payments", "features_team_payments.gen.go"},
+		{"features.go", "linux", "features_linux_gbgen.go"},
+		{"features.go", "arm64", "features_arm64_gbgen.go"},
+		{"features.go", "test", "features_test_gbgen.go"},
+		{"features.gen.go", "test", "features_test.gen.go"},
+	} {
+		if got := splitFileName(tc.main, fileSlug(tc.label)); got != tc.want {
+			t.Errorf("splitFileName(%q, %q) = %q, want %q", tc.main, tc.label, got, tc.want)
+		}
+	}
+}
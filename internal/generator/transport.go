@@ -0,0 +1,99 @@
+package generator
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/eastnine90/gbgen/internal/buildinfo"
+	"github.com/eastnine90/gbgen/internal/config"
+)
+
+// newHTTPClient builds the base HTTP client from growthbook.http: proxy, extra root CAs, client certificate,
+// custom headers and User-Agent. Timeouts are applied per attempt by retryDoer, not here.
+func newHTTPClient(cfg config.HTTPConfig) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if cfg.ProxyURL != "" {
+		proxyURL, err := url.Parse(cfg.ProxyURL)
+		if err != nil {
+			return nil, errors.New("growthbook.http.proxyURL: invalid URL")
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if cfg.CABundle != "" || cfg.ClientCertFile != "" {
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+		if cfg.CABundle != "" {
+			pool, err := x509.SystemCertPool()
+			if err != nil {
+				pool = x509.NewCertPool()
+			}
+			pem, err := os.ReadFile(cfg.CABundle)
+			if err != nil {
+				return nil, fmt.Errorf("growthbook.http.caBundle: %w", err)
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("growthbook.http.caBundle: no PEM certificates in %s", cfg.CABundle)
+			}
+			tlsConfig.RootCAs = pool
+		}
+		if cfg.ClientCertFile != "" {
+			cert, err := tls.LoadX509KeyPair(cfg.ClientCertFile, cfg.ClientKeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("growthbook.http.clientCertFile / clientKeyFile: %w", err)
+			}
+			tlsConfig.Certificates = []tls.Certificate{cert}
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	userAgent := cfg.UserAgent
+	if userAgent == "" {
+		userAgent = "gbgen/" + buildinfo.Version
+	}
+	return &http.Client{
+		Transport: &headerTransport{next: transport, headers: cfg.Headers, userAgent: userAgent},
+	}, nil
+}
+
+// headerTransport adds the configured headers and User-Agent to every request.
+type headerTransport struct {
+	next      http.RoundTripper
+	headers   map[string]string
+	userAgent string
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for k, v := range t.headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("User-Agent", t.userAgent)
+	return t.next.RoundTrip(req)
+}
+
+// restBaseURL joins growthbook.apiBaseURL and growthbook.apiPath (default "/api/v1"; "/" for none).
+// The path is not appended again if the base URL already ends with it.
+func restBaseURL(gb config.GrowthBookConfig) string {
+	base := strings.TrimRight(gb.APIBaseURL, "/")
+	apiPath := restAPIPath(gb)
+	if apiPath == "/" || strings.HasSuffix(base, apiPath) {
+		return base
+	}
+	return base + apiPath
+}
+
+// restAPIPath returns growthbook.apiPath with a single leading slash and no trailing one, "/api/v1" by default.
+func restAPIPath(gb config.GrowthBookConfig) string {
+	apiPath := gb.APIPath
+	if apiPath == "" {
+		apiPath = "/api/v1"
+	}
+	return "/" + strings.Trim(apiPath, "/")
+}
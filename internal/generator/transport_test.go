@@ -0,0 +1,167 @@
+package generator
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/eastnine90/gbgen/internal/config"
+)
+
+const emptyFeaturesBody = `{"features":[],"hasMore":false}`
+
+func TestNewGenerator_HTTPSettings(t *testing.T) {
+	var got *http.Request
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(emptyFeaturesBody))
+	}))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	srv.StartTLS()
+	defer srv.Close()
+
+	dir := t.TempDir()
+	caPath := filepath.Join(dir, "ca.pem")
+	writePEM(t, caPath, "CERTIFICATE", srv.Certificate().Raw)
+	certPath, keyPath := writeClientCert(t, dir)
+
+	g, err := NewGenerator(config.Config{
+		GrowthBook: config.GrowthBookConfig{
+			APIBaseURL: srv.URL,
+			APIPath:    "/growthbook/api/v1",
+			APIKey:     "secret_abc",
+			HTTP: config.HTTPConfig{
+				CABundle:       caPath,
+				ClientCertFile: certPath,
+				ClientKeyFile:  keyPath,
+				Headers:        map[string]string{"X-Tenant": "platform"},
+				UserAgent:      "ci-gbgen/1.0",
+			},
+		},
+		Generator: config.GeneratorConfig{PackageName: "features"},
+	})
+	if err != nil {
+		t.Fatalf("NewGenerator error: %v", err)
+	}
+	if _, err := g.Generate(context.Background()); err != nil {
+		t.Fatalf("Generate error: %v", err)
+	}
+
+	if got.URL.Path != "/growthbook/api/v1/features" {
+		t.Fatalf("path = %q", got.URL.Path)
+	}
+	if got.Header.Get("X-Tenant") != "platform" || got.Header.Get("User-Agent") != "ci-gbgen/1.0" {
+		t.Fatalf("headers = %v", got.Header)
+	}
+	if len(got.TLS.PeerCertificates) != 1 {
+		t.Fatalf("expected a client certificate, got %d", len(got.TLS.PeerCertificates))
+	}
+}
+
+func TestNewGenerator_ProxyURL(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(emptyFeaturesBody))
+	}))
+	defer proxy.Close()
+
+	g, err := NewGenerator(config.Config{
+		GrowthBook: config.GrowthBookConfig{
+			APIBaseURL: "http://growthbook.internal.example",
+			APIKey:     "secret_abc",
+			HTTP:       config.HTTPConfig{ProxyURL: proxy.URL},
+		},
+		Generator: config.GeneratorConfig{PackageName: "features"},
+	})
+	if err != nil {
+		t.Fatalf("NewGenerator error: %v", err)
+	}
+	if _, err := g.Generate(context.Background()); err != nil {
+		t.Fatalf("Generate error: %v", err)
+	}
+	if proxied != "http://growthbook.internal.example/api/v1/features?limit=100&offset=0" {
+		t.Fatalf("proxied request = %q", proxied)
+	}
+}
+
+func TestRestBaseURL(t *testing.T) {
+	tests := []struct {
+		base, apiPath, want string
+	}{
+		{base: "https://api.growthbook.io", want: "https://api.growthbook.io/api/v1"},
+		{base: "https://api.growthbook.io/api/v1/", apiPath: "/api/v1", want: "https://api.growthbook.io/api/v1"},
+		{base: "https://gb.example.com", apiPath: "gb-api/v1/", want: "https://gb.example.com/gb-api/v1"},
+		{base: "https://gb.example.com/v1", apiPath: "/", want: "https://gb.example.com/v1"},
+	}
+	for _, tt := range tests {
+		if got := restBaseURL(config.GrowthBookConfig{APIBaseURL: tt.base, APIPath: tt.apiPath}); got != tt.want {
+			t.Errorf("restBaseURL(%q, %q) = %q, want %q", tt.base, tt.apiPath, got, tt.want)
+		}
+	}
+}
+
+func TestSDKPayloadURL(t *testing.T) {
+	tests := []struct {
+		base, apiPath, want string
+	}{
+		{base: "https://api.growthbook.io", want: "https://api.growthbook.io/api/features/sdk-1"},
+		{base: "https://api.growthbook.io/api/v1/", want: "https://api.growthbook.io/api/features/sdk-1"},
+		{base: "https://gb.example.com/gb-api/v1", apiPath: "gb-api/v1/", want: "https://gb.example.com/api/features/sdk-1"},
+		{base: "https://gb.example.com/api/v1", apiPath: "/gb-api/v1", want: "https://gb.example.com/api/v1/api/features/sdk-1"},
+		{base: "https://gb.example.com", apiPath: "/", want: "https://gb.example.com/api/features/sdk-1"},
+	}
+	for _, tt := range tests {
+		if got := sdkPayloadURL(config.GrowthBookConfig{APIBaseURL: tt.base, APIPath: tt.apiPath, ClientKey: "sdk-1"}); got != tt.want {
+			t.Errorf("sdkPayloadURL(%q, %q) = %q, want %q", tt.base, tt.apiPath, got, tt.want)
+		}
+	}
+}
+
+func writeClientCert(t *testing.T, dir string) (certPath, keyPath string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "gbgen-test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPath, keyPath = filepath.Join(dir, "client.pem"), filepath.Join(dir, "client-key.pem")
+	writePEM(t, certPath, "CERTIFICATE", der)
+	writePEM(t, keyPath, "EC PRIVATE KEY", keyDER)
+	return certPath, keyPath
+}
+
+func writePEM(t *testing.T, path, blockType string, der []byte) {
+	t.Helper()
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
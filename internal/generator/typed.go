@@ -3,19 +3,28 @@ package generator
 import (
 	"bytes"
 	"fmt"
-	"strings"
 
 	"github.com/eastnine90/gbgen/internal/buildinfo"
 	"github.com/eastnine90/gbgen/internal/growthbookapi"
 )
 
-func renderTypedFeaturesGo(pkg string, features []featureMeta, emitList bool) ([]byte, error) {
+func renderTypedFeaturesGo(pkg string, features []featureMeta, emitList bool, sections ...declSection) ([]byte, error) {
+	lines := nameAndDedupe(features)
+	return renderTypedFeaturesFile(pkg, lines, lines, emitList, sections)
+}
+
+// renderTypedFeaturesFile renders the main output file: the package doc, the shared declarations, the typed features
+// of own and the feature list of all features.
+func renderTypedFeaturesFile(pkg string, own, all []namedFeature, emitList bool, sections []declSection) ([]byte, error) {
 	pkgName := pkg
 	if pkgName == "" {
 		pkgName = "features"
 	}
 
-	lines := nameAndDedupe(features)
+	var baseImports []string
+	if len(own) > 0 {
+		baseImports = []string{typesImport}
+	}
 
 	preamble, err := renderPreamble(preambleOptions{
 		PackageName:  pkgName,
@@ -30,7 +39,7 @@ func renderTypedFeaturesGo(pkg string, features []featureMeta, emitList bool) ([
 			"\tres, err := " + "FeatureExample.Evaluate(ctx, client)",
 			"\t_ = res; _ = err",
 		},
-		Imports: []string{"github.com/eastnine90/gbgen/types"},
+		Imports: sectionImports(baseImports, sections),
 	})
 	if err != nil {
 		return nil, err
@@ -43,51 +52,72 @@ func renderTypedFeaturesGo(pkg string, features []featureMeta, emitList bool) ([
 		fmt.Fprintf(&b, "type FeatureKey string\n\n")
 	}
 
-	fmt.Fprintf(&b, "const (\n")
-	for _, l := range lines {
-		desc := strings.TrimSpace(l.Description)
-		if desc != "" {
-			for _, line := range strings.Split(desc, "\n") {
-				line = strings.TrimSpace(line)
-				if line == "" {
-					continue
-				}
-				fmt.Fprintf(&b, "\t// %s\n", line)
-			}
-		}
-		if l.NoActiveEnvs {
-			fmt.Fprintf(&b, "\t// Deprecated: no active environments\n")
+	// With generator.split every feature may live in another file; an empty package keeps its empty const block.
+	if len(own) > 0 || len(all) == 0 {
+		if err := writeTypedFeatureConsts(&b, own); err != nil {
+			return nil, err
 		}
-
-		typeExpr, err := typedFeatureTypeExpr(l.ValueType)
-		if err != nil {
-			return nil, fmt.Errorf("feature %q: %w", l.ID, err)
-		}
-
-		fmt.Fprintf(&b, "\t%s = %s(%q)\n", l.Name, typeExpr, l.ID)
 	}
-	fmt.Fprintf(&b, ")\n")
 
 	if emitList {
 		fmt.Fprintf(&b, "\nvar FeatureList = []FeatureKey{\n")
-		for _, l := range lines {
+		for _, l := range all {
 			fmt.Fprintf(&b, "\tFeatureKey(%q),\n", l.ID)
 		}
 		fmt.Fprintf(&b, "}\n")
 	}
 
+	for _, s := range sections {
+		fmt.Fprintf(&b, "\n")
+		b.Write(s.Body)
+	}
+
+	return formatGo(b.Bytes())
+}
+
+// renderTypedFeaturesPart renders a file holding only the typed features of lines (see generator.split).
+func renderTypedFeaturesPart(pkg string, lines []namedFeature) ([]byte, error) {
+	// Only the main file carries the package doc comment.
+	header, err := renderPreamble(preambleOptions{PackageName: pkg, GBGenVersion: buildinfo.Version, Imports: []string{typesImport}})
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	b.Write(header)
+	if err := writeTypedFeatureConsts(&b, lines); err != nil {
+		return nil, err
+	}
 	return formatGo(b.Bytes())
 }
 
+const typesImport = "github.com/eastnine90/gbgen/types"
+
+func writeTypedFeatureConsts(b *bytes.Buffer, lines []namedFeature) error {
+	fmt.Fprintf(b, "const (\n")
+	for _, l := range lines {
+		writeFeatureDoc(b, l)
+
+		typeExpr, err := typedFeatureTypeExpr(l.ValueType)
+		if err != nil {
+			return fmt.Errorf("feature %q: %w", l.ID, err)
+		}
+
+		fmt.Fprintf(b, "\t%s = %s(%q)\n", l.Name, typeExpr, l.ID)
+	}
+	fmt.Fprintf(b, ")\n")
+	return nil
+}
+
 func typedFeatureTypeExpr(vt growthbookapi.FeatureValueType) (string, error) {
 	switch vt {
-	case growthbookapi.Boolean:
+	case growthbookapi.FeatureValueTypeBoolean:
 		return "types.BooleanFeature", nil
-	case growthbookapi.String:
+	case growthbookapi.FeatureValueTypeString:
 		return "types.StringFeature", nil
-	case growthbookapi.Number:
+	case growthbookapi.FeatureValueTypeNumber:
 		return "types.NumberFeature", nil
-	case growthbookapi.Json:
+	case growthbookapi.FeatureValueTypeJson:
 		return "types.JSONFeature", nil
 	default:
 		return "", fmt.Errorf("unsupported valueType %q", string(vt))
@@ -22,12 +22,39 @@ const (
 	BearerAuthScopes = "bearerAuth.Scopes"
 )
 
+// Defines values for AttributeDatatype.
+const (
+	AttributeDatatypeBoolean       AttributeDatatype = "boolean"
+	AttributeDatatypeEnum          AttributeDatatype = "enum"
+	AttributeDatatypeNumber        AttributeDatatype = "number"
+	AttributeDatatypeNumber1       AttributeDatatype = "number[]"
+	AttributeDatatypeSecureString  AttributeDatatype = "secureString"
+	AttributeDatatypeSecureString1 AttributeDatatype = "secureString[]"
+	AttributeDatatypeString        AttributeDatatype = "string"
+	AttributeDatatypeString1       AttributeDatatype = "string[]"
+)
+
+// Defines values for AttributeFormat.
+const (
+	Date           AttributeFormat = "date"
+	Empty          AttributeFormat = ""
+	IsoCountryCode AttributeFormat = "isoCountryCode"
+	Version        AttributeFormat = "version"
+)
+
+// Defines values for ExperimentStatus.
+const (
+	ExperimentStatusDraft   ExperimentStatus = "draft"
+	ExperimentStatusRunning ExperimentStatus = "running"
+	ExperimentStatusStopped ExperimentStatus = "stopped"
+)
+
 // Defines values for FeatureValueType.
 const (
-	Boolean FeatureValueType = "boolean"
-	Json    FeatureValueType = "json"
-	Number  FeatureValueType = "number"
-	String  FeatureValueType = "string"
+	FeatureValueTypeBoolean FeatureValueType = "boolean"
+	FeatureValueTypeJson    FeatureValueType = "json"
+	FeatureValueTypeNumber  FeatureValueType = "number"
+	FeatureValueTypeString  FeatureValueType = "string"
 )
 
 // Defines values for FeatureExperimentRefRuleType.
@@ -37,7 +64,7 @@ const (
 
 // Defines values for FeatureExperimentRuleType.
 const (
-	Experiment FeatureExperimentRuleType = "experiment"
+	FeatureExperimentRuleTypeExperiment FeatureExperimentRuleType = "experiment"
 )
 
 // Defines values for FeatureForceRuleSavedGroupTargetingMatchType.
@@ -73,10 +100,10 @@ const (
 
 // Defines values for FeatureSafeRolloutRuleStatus.
 const (
-	Released   FeatureSafeRolloutRuleStatus = "released"
-	RolledBack FeatureSafeRolloutRuleStatus = "rolled-back"
-	Running    FeatureSafeRolloutRuleStatus = "running"
-	Stopped    FeatureSafeRolloutRuleStatus = "stopped"
+	FeatureSafeRolloutRuleStatusReleased   FeatureSafeRolloutRuleStatus = "released"
+	FeatureSafeRolloutRuleStatusRolledBack FeatureSafeRolloutRuleStatus = "rolled-back"
+	FeatureSafeRolloutRuleStatusRunning    FeatureSafeRolloutRuleStatus = "running"
+	FeatureSafeRolloutRuleStatusStopped    FeatureSafeRolloutRuleStatus = "stopped"
 )
 
 // Defines values for FeatureSafeRolloutRuleType.
@@ -84,6 +111,66 @@ const (
 	SafeRollout FeatureSafeRolloutRuleType = "safe-rollout"
 )
 
+// Defines values for SavedGroupType.
+const (
+	Condition SavedGroupType = "condition"
+	List      SavedGroupType = "list"
+)
+
+// Attribute defines model for Attribute.
+type Attribute struct {
+	Archived      *bool             `json:"archived,omitempty"`
+	Datatype      AttributeDatatype `json:"datatype"`
+	Description   *string           `json:"description,omitempty"`
+	Enum          *string           `json:"enum,omitempty"`
+	Format        *AttributeFormat  `json:"format,omitempty"`
+	HashAttribute *bool             `json:"hashAttribute,omitempty"`
+	Projects      *[]string         `json:"projects,omitempty"`
+	Property      string            `json:"property"`
+}
+
+// AttributeDatatype defines model for Attribute.Datatype.
+type AttributeDatatype string
+
+// AttributeFormat defines model for Attribute.Format.
+type AttributeFormat string
+
+// Environment defines model for Environment.
+type Environment struct {
+	DefaultState bool     `json:"defaultState"`
+	Description  string   `json:"description"`
+	Id           string   `json:"id"`
+	Projects     []string `json:"projects"`
+	ToggleOnList bool     `json:"toggleOnList"`
+}
+
+// Experiment defines model for Experiment.
+type Experiment struct {
+	Archived       bool             `json:"archived"`
+	DateCreated    time.Time        `json:"dateCreated"`
+	DateUpdated    time.Time        `json:"dateUpdated"`
+	Description    string           `json:"description"`
+	HashAttribute  string           `json:"hashAttribute"`
+	Hypothesis     string           `json:"hypothesis"`
+	Id             string           `json:"id"`
+	LinkedFeatures *[]string        `json:"linkedFeatures,omitempty"`
+	Name           string           `json:"name"`
+	Owner          string           `json:"owner"`
+	Project        string           `json:"project"`
+	Status         ExperimentStatus `json:"status"`
+	Tags           []string         `json:"tags"`
+	TrackingKey    string           `json:"trackingKey"`
+	Variations     []struct {
+		Description *string `json:"description,omitempty"`
+		Key         string  `json:"key"`
+		Name        string  `json:"name"`
+		VariationId string  `json:"variationId"`
+	} `json:"variations"`
+}
+
+// ExperimentStatus defines model for Experiment.Status.
+type ExperimentStatus string
+
 // Feature defines model for Feature.
 type Feature struct {
 	Archived     bool                          `json:"archived"`
@@ -278,6 +365,38 @@ type PaginationFields struct {
 	Total      int  `json:"total"`
 }
 
+// Project defines model for Project.
+type Project struct {
+	DateCreated time.Time `json:"dateCreated"`
+	DateUpdated time.Time `json:"dateUpdated"`
+	Description *string   `json:"description,omitempty"`
+	Id          string    `json:"id"`
+	Name        string    `json:"name"`
+}
+
+// SavedGroup defines model for SavedGroup.
+type SavedGroup struct {
+	// AttributeKey When type = 'list', this is the attribute key the group is based on
+	AttributeKey *string `json:"attributeKey,omitempty"`
+
+	// Condition When type = 'condition', this is the JSON-encoded condition for the group
+	Condition   *string        `json:"condition,omitempty"`
+	DateCreated time.Time      `json:"dateCreated"`
+	DateUpdated time.Time      `json:"dateUpdated"`
+	Description *string        `json:"description,omitempty"`
+	Id          string         `json:"id"`
+	Name        string         `json:"name"`
+	Owner       *string        `json:"owner,omitempty"`
+	Projects    *[]string      `json:"projects,omitempty"`
+	Type        SavedGroupType `json:"type"`
+
+	// Values When type = 'list', this is the list of values for the attribute key
+	Values *[]string `json:"values,omitempty"`
+}
+
+// SavedGroupType defines model for SavedGroup.Type.
+type SavedGroupType string
+
 // ScheduleRule An array of schedule rules to turn on/off a feature rule at specific times. The array must contain exactly 2 elements (start rule and end rule). The first element is the start rule.
 type ScheduleRule struct {
 	// Enabled Whether the rule should be enabled or disabled at the specified timestamp.
@@ -305,6 +424,18 @@ type ProjectId = string
 // VisualChangeId defines model for visualChangeId.
 type VisualChangeId = string
 
+// ListExperimentsParams defines parameters for ListExperiments.
+type ListExperimentsParams struct {
+	// Limit The number of items to return
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset How many items to skip (use in conjunction with limit for pagination)
+	Offset *Offset `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// ProjectId Filter by project id
+	ProjectId *ProjectId `form:"projectId,omitempty" json:"projectId,omitempty"`
+}
+
 // ListFeaturesParams defines parameters for ListFeatures.
 type ListFeaturesParams struct {
 	// Limit The number of items to return
@@ -320,6 +451,24 @@ type ListFeaturesParams struct {
 	ClientKey *ClientKey `form:"clientKey,omitempty" json:"clientKey,omitempty"`
 }
 
+// ListProjectsParams defines parameters for ListProjects.
+type ListProjectsParams struct {
+	// Limit The number of items to return
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset How many items to skip (use in conjunction with limit for pagination)
+	Offset *Offset `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// ListSavedGroupsParams defines parameters for ListSavedGroups.
+type ListSavedGroupsParams struct {
+	// Limit The number of items to return
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset How many items to skip (use in conjunction with limit for pagination)
+	Offset *Offset `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
 // AsFeatureForceRule returns the union data inside the FeatureRule as a FeatureForceRule
 func (t FeatureRule) AsFeatureForceRule() (FeatureForceRule, error) {
 	var body FeatureForceRule
@@ -572,8 +721,59 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// ListAttributes request
+	ListAttributes(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListEnvironments request
+	ListEnvironments(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListExperiments request
+	ListExperiments(ctx context.Context, params *ListExperimentsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// ListFeatures request
 	ListFeatures(ctx context.Context, params *ListFeaturesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListProjects request
+	ListProjects(ctx context.Context, params *ListProjectsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListSavedGroups request
+	ListSavedGroups(ctx context.Context, params *ListSavedGroupsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) ListAttributes(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListAttributesRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListEnvironments(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListEnvironmentsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListExperiments(ctx context.Context, params *ListExperimentsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListExperimentsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
 func (c *Client) ListFeatures(ctx context.Context, params *ListFeaturesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -588,6 +788,165 @@ func (c *Client) ListFeatures(ctx context.Context, params *ListFeaturesParams, r
 	return c.Client.Do(req)
 }
 
+func (c *Client) ListProjects(ctx context.Context, params *ListProjectsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListProjectsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListSavedGroups(ctx context.Context, params *ListSavedGroupsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListSavedGroupsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewListAttributesRequest generates requests for ListAttributes
+func NewListAttributesRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/attributes")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListEnvironmentsRequest generates requests for ListEnvironments
+func NewListEnvironmentsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/environments")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListExperimentsRequest generates requests for ListExperiments
+func NewListExperimentsRequest(server string, params *ListExperimentsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/experiments")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Offset != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "offset", runtime.ParamLocationQuery, *params.Offset); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.ProjectId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "projectId", runtime.ParamLocationQuery, *params.ProjectId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewListFeaturesRequest generates requests for ListFeatures
 func NewListFeaturesRequest(server string, params *ListFeaturesParams) (*http.Request, error) {
 	var err error
@@ -685,29 +1044,159 @@ func NewListFeaturesRequest(server string, params *ListFeaturesParams) (*http.Re
 	return req, nil
 }
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
-	}
-	for _, r := range additionalEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-// ClientWithResponses builds on ClientInterface to offer response payloads
-type ClientWithResponses struct {
-	ClientInterface
-}
+// NewListProjectsRequest generates requests for ListProjects
+func NewListProjectsRequest(server string, params *ListProjectsParams) (*http.Request, error) {
+	var err error
 
-// NewClientWithResponses creates a new ClientWithResponses, which wraps
-// Client with return type handling
-func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
-	client, err := NewClient(server, opts...)
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/projects")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Offset != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "offset", runtime.ParamLocationQuery, *params.Offset); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListSavedGroupsRequest generates requests for ListSavedGroups
+func NewListSavedGroupsRequest(server string, params *ListSavedGroupsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/saved-groups")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Offset != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "offset", runtime.ParamLocationQuery, *params.Offset); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -728,8 +1217,101 @@ func WithBaseURL(baseURL string) ClientOption {
 
 // ClientWithResponsesInterface is the interface specification for the client with responses above.
 type ClientWithResponsesInterface interface {
+	// ListAttributesWithResponse request
+	ListAttributesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListAttributesResponse, error)
+
+	// ListEnvironmentsWithResponse request
+	ListEnvironmentsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListEnvironmentsResponse, error)
+
+	// ListExperimentsWithResponse request
+	ListExperimentsWithResponse(ctx context.Context, params *ListExperimentsParams, reqEditors ...RequestEditorFn) (*ListExperimentsResponse, error)
+
 	// ListFeaturesWithResponse request
 	ListFeaturesWithResponse(ctx context.Context, params *ListFeaturesParams, reqEditors ...RequestEditorFn) (*ListFeaturesResponse, error)
+
+	// ListProjectsWithResponse request
+	ListProjectsWithResponse(ctx context.Context, params *ListProjectsParams, reqEditors ...RequestEditorFn) (*ListProjectsResponse, error)
+
+	// ListSavedGroupsWithResponse request
+	ListSavedGroupsWithResponse(ctx context.Context, params *ListSavedGroupsParams, reqEditors ...RequestEditorFn) (*ListSavedGroupsResponse, error)
+}
+
+type ListAttributesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Attributes []Attribute `json:"attributes"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r ListAttributesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListAttributesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListEnvironmentsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Environments []Environment `json:"environments"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r ListEnvironmentsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListEnvironmentsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListExperimentsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Count       int          `json:"count"`
+		Experiments []Experiment `json:"experiments"`
+		HasMore     bool         `json:"hasMore"`
+		Limit       int          `json:"limit"`
+		NextOffset  *int         `json:"nextOffset"`
+		Offset      int          `json:"offset"`
+		Total       int          `json:"total"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r ListExperimentsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListExperimentsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
 }
 
 type ListFeaturesResponse struct {
@@ -762,6 +1344,93 @@ func (r ListFeaturesResponse) StatusCode() int {
 	return 0
 }
 
+type ListProjectsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Count      int       `json:"count"`
+		HasMore    bool      `json:"hasMore"`
+		Limit      int       `json:"limit"`
+		NextOffset *int      `json:"nextOffset"`
+		Offset     int       `json:"offset"`
+		Projects   []Project `json:"projects"`
+		Total      int       `json:"total"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r ListProjectsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListProjectsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListSavedGroupsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Count       int          `json:"count"`
+		HasMore     bool         `json:"hasMore"`
+		Limit       int          `json:"limit"`
+		NextOffset  *int         `json:"nextOffset"`
+		Offset      int          `json:"offset"`
+		SavedGroups []SavedGroup `json:"savedGroups"`
+		Total       int          `json:"total"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r ListSavedGroupsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListSavedGroupsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ListAttributesWithResponse request returning *ListAttributesResponse
+func (c *ClientWithResponses) ListAttributesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListAttributesResponse, error) {
+	rsp, err := c.ListAttributes(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListAttributesResponse(rsp)
+}
+
+// ListEnvironmentsWithResponse request returning *ListEnvironmentsResponse
+func (c *ClientWithResponses) ListEnvironmentsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListEnvironmentsResponse, error) {
+	rsp, err := c.ListEnvironments(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListEnvironmentsResponse(rsp)
+}
+
+// ListExperimentsWithResponse request returning *ListExperimentsResponse
+func (c *ClientWithResponses) ListExperimentsWithResponse(ctx context.Context, params *ListExperimentsParams, reqEditors ...RequestEditorFn) (*ListExperimentsResponse, error) {
+	rsp, err := c.ListExperiments(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListExperimentsResponse(rsp)
+}
+
 // ListFeaturesWithResponse request returning *ListFeaturesResponse
 func (c *ClientWithResponses) ListFeaturesWithResponse(ctx context.Context, params *ListFeaturesParams, reqEditors ...RequestEditorFn) (*ListFeaturesResponse, error) {
 	rsp, err := c.ListFeatures(ctx, params, reqEditors...)
@@ -771,6 +1440,114 @@ func (c *ClientWithResponses) ListFeaturesWithResponse(ctx context.Context, para
 	return ParseListFeaturesResponse(rsp)
 }
 
+// ListProjectsWithResponse request returning *ListProjectsResponse
+func (c *ClientWithResponses) ListProjectsWithResponse(ctx context.Context, params *ListProjectsParams, reqEditors ...RequestEditorFn) (*ListProjectsResponse, error) {
+	rsp, err := c.ListProjects(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListProjectsResponse(rsp)
+}
+
+// ListSavedGroupsWithResponse request returning *ListSavedGroupsResponse
+func (c *ClientWithResponses) ListSavedGroupsWithResponse(ctx context.Context, params *ListSavedGroupsParams, reqEditors ...RequestEditorFn) (*ListSavedGroupsResponse, error) {
+	rsp, err := c.ListSavedGroups(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListSavedGroupsResponse(rsp)
+}
+
+// ParseListAttributesResponse parses an HTTP response from a ListAttributesWithResponse call
+func ParseListAttributesResponse(rsp *http.Response) (*ListAttributesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListAttributesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Attributes []Attribute `json:"attributes"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListEnvironmentsResponse parses an HTTP response from a ListEnvironmentsWithResponse call
+func ParseListEnvironmentsResponse(rsp *http.Response) (*ListEnvironmentsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListEnvironmentsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Environments []Environment `json:"environments"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListExperimentsResponse parses an HTTP response from a ListExperimentsWithResponse call
+func ParseListExperimentsResponse(rsp *http.Response) (*ListExperimentsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListExperimentsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Count       int          `json:"count"`
+			Experiments []Experiment `json:"experiments"`
+			HasMore     bool         `json:"hasMore"`
+			Limit       int          `json:"limit"`
+			NextOffset  *int         `json:"nextOffset"`
+			Offset      int          `json:"offset"`
+			Total       int          `json:"total"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseListFeaturesResponse parses an HTTP response from a ListFeaturesWithResponse call
 func ParseListFeaturesResponse(rsp *http.Response) (*ListFeaturesResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -804,3 +1581,71 @@ func ParseListFeaturesResponse(rsp *http.Response) (*ListFeaturesResponse, error
 
 	return response, nil
 }
+
+// ParseListProjectsResponse parses an HTTP response from a ListProjectsWithResponse call
+func ParseListProjectsResponse(rsp *http.Response) (*ListProjectsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListProjectsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Count      int       `json:"count"`
+			HasMore    bool      `json:"hasMore"`
+			Limit      int       `json:"limit"`
+			NextOffset *int      `json:"nextOffset"`
+			Offset     int       `json:"offset"`
+			Projects   []Project `json:"projects"`
+			Total      int       `json:"total"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListSavedGroupsResponse parses an HTTP response from a ListSavedGroupsWithResponse call
+func ParseListSavedGroupsResponse(rsp *http.Response) (*ListSavedGroupsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListSavedGroupsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Count       int          `json:"count"`
+			HasMore     bool         `json:"hasMore"`
+			Limit       int          `json:"limit"`
+			NextOffset  *int         `json:"nextOffset"`
+			Offset      int          `json:"offset"`
+			SavedGroups []SavedGroup `json:"savedGroups"`
+			Total       int          `json:"total"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
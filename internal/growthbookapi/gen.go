@@ -1,3 +1,3 @@
 package growthbookapi
 
-//go:generate go tool oapi-codegen -include-operation-ids listFeatures -package growthbookapi -generate types,client -o client.go $GEN_OPENAPI_FILE
+//go:generate go tool oapi-codegen -include-operation-ids $GEN_OPERATION_IDS -package growthbookapi -generate types,client -o client.go $GEN_OPENAPI_FILE
@@ -0,0 +1,220 @@
+package httpcache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// entrySuffix marks files owned by the cache so Clear never removes anything else.
+const (
+	entrySuffix = ".gbcache.json"
+	tempPrefix  = ".gbcache-tmp-"
+)
+
+// Doer performs HTTP requests (satisfied by *http.Client and growthbookapi.HttpRequestDoer).
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Cache is a Doer that serves GET responses from an on-disk cache.
+type Cache struct {
+	next    Doer
+	dir     string
+	ttl     time.Duration
+	headers map[string]string
+
+	// now is replaceable in tests.
+	now func() time.Time
+}
+
+// New returns a Cache storing entries in dir and serving them without revalidation for ttl. headers are the extra
+// headers next adds to every request (growthbook.http.headers); they are part of the cache key, as they may change
+// the response.
+func New(next Doer, dir string, ttl time.Duration, headers map[string]string) *Cache {
+	return &Cache{next: next, dir: dir, ttl: ttl, headers: headers, now: time.Now}
+}
+
+// ResolveDir returns dir, or the default cache directory (<user cache dir>/gbgen) if dir is empty.
+func ResolveDir(dir string) (string, error) {
+	if dir != "" {
+		return dir, nil
+	}
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "gbgen"), nil
+}
+
+// Clear removes all cache entries in dir, and dir itself if it is left empty.
+// A missing directory is not an error.
+func Clear(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	for _, e := range entries {
+		if e.IsDir() || !(strings.HasSuffix(e.Name(), entrySuffix) || strings.HasPrefix(e.Name(), tempPrefix)) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, e.Name())); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	// Only succeeds if nothing else lives in dir.
+	_ = os.Remove(dir)
+	return nil
+}
+
+type entry struct {
+	StoredAt     time.Time `json:"storedAt"`
+	ContentType  string    `json:"contentType"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	Body         []byte    `json:"body"`
+}
+
+func (c *Cache) Do(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return c.next.Do(req)
+	}
+
+	path := c.entryPath(req)
+	cached, ok := c.load(path)
+	if ok && c.now().Sub(cached.StoredAt) < c.ttl {
+		return cached.response(req), nil
+	}
+
+	outReq := req
+	if ok && (cached.ETag != "" || cached.LastModified != "") {
+		outReq = req.Clone(req.Context())
+		if cached.ETag != "" {
+			outReq.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			outReq.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	resp, err := c.next.Do(outReq)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && ok:
+		_ = resp.Body.Close()
+		cached.StoredAt = c.now()
+		c.store(path, cached)
+		return cached.response(req), nil
+
+	case resp.StatusCode == http.StatusOK:
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		c.store(path, entry{
+			StoredAt:     c.now(),
+			ContentType:  resp.Header.Get("Content-Type"),
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+			Body:         body,
+		})
+		return resp, nil
+
+	default:
+		return resp, nil
+	}
+}
+
+// entryPath derives the entry file from the request URL, credentials and extra headers.
+// Credentials are hashed into the key and never stored.
+func (c *Cache) entryPath(req *http.Request) string {
+	h := sha256.New()
+	_, _ = io.WriteString(h, req.URL.String())
+	_, _ = io.WriteString(h, "\n")
+	_, _ = io.WriteString(h, req.Header.Get("Authorization"))
+
+	lines := make([]string, 0, len(c.headers))
+	for k, v := range c.headers {
+		lines = append(lines, http.CanonicalHeaderKey(k)+": "+v)
+	}
+	sort.Strings(lines)
+	for _, line := range lines {
+		_, _ = io.WriteString(h, "\n")
+		_, _ = io.WriteString(h, line)
+	}
+	return filepath.Join(c.dir, hex.EncodeToString(h.Sum(nil))+entrySuffix)
+}
+
+func (c *Cache) load(path string) (entry, bool) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return entry{}, false
+	}
+	var e entry
+	if err := json.Unmarshal(b, &e); err != nil {
+		return entry{}, false
+	}
+	return e, true
+}
+
+// store writes an entry atomically. Failures are ignored: the cache is an optimization only.
+func (c *Cache) store(path string, e entry) {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(c.dir, 0o700); err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(c.dir, tempPrefix+"*")
+	if err != nil {
+		return
+	}
+	_, werr := tmp.Write(b)
+	cerr := tmp.Close()
+	if werr != nil || cerr != nil {
+		_ = os.Remove(tmp.Name())
+		return
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+}
+
+func (e entry) response(req *http.Request) *http.Response {
+	header := http.Header{}
+	if e.ContentType != "" {
+		header.Set("Content-Type", e.ContentType)
+	}
+	if e.ETag != "" {
+		header.Set("ETag", e.ETag)
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,130 @@
+package httpcache
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCache_FreshRevalidateAndClear(t *testing.T) {
+	var requests, notModified int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `W/"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `W/"v1"`)
+		_, _ = w.Write([]byte(`{"features":[]}`))
+	}))
+	defer srv.Close()
+
+	dir := filepath.Join(t.TempDir(), "cache")
+	now := time.Unix(1_700_000_000, 0)
+	c := New(srv.Client(), dir, time.Minute, nil)
+	c.now = func() time.Time { return now }
+
+	get := func() string {
+		t.Helper()
+		req, _ := http.NewRequest(http.MethodGet, srv.URL+"/api/v1/features?projectId=p1", nil)
+		req.Header.Set("Authorization", "Bearer secret_x")
+		resp, err := c.Do(req)
+		if err != nil {
+			t.Fatalf("Do error: %v", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("status = %d", resp.StatusCode)
+		}
+		b, _ := io.ReadAll(resp.Body)
+		return string(b)
+	}
+
+	if got := get(); got != `{"features":[]}` {
+		t.Fatalf("body = %q", got)
+	}
+	// Within the TTL: served from disk.
+	now = now.Add(30 * time.Second)
+	if got := get(); got != `{"features":[]}` || requests != 1 {
+		t.Fatalf("expected cached body without a request, got %q after %d requests", got, requests)
+	}
+	// After the TTL: revalidated with the stored ETag.
+	now = now.Add(time.Minute)
+	if got := get(); got != `{"features":[]}` || requests != 2 || notModified != 1 {
+		t.Fatalf("expected a 304 revalidation, got %q (requests=%d, notModified=%d)", got, requests, notModified)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 cache entry, got %d", len(entries))
+	}
+	if err := Clear(dir); err != nil {
+		t.Fatalf("Clear error: %v", err)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Fatalf("expected cache dir to be removed, got %v", err)
+	}
+	if err := Clear(dir); err != nil {
+		t.Fatalf("Clear on missing dir error: %v", err)
+	}
+}
+
+func TestCache_KeyedByCredentials(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(r.Header.Get("Authorization")))
+	}))
+	defer srv.Close()
+
+	c := New(srv.Client(), t.TempDir(), time.Hour, nil)
+	for _, auth := range []string{"Bearer a", "Bearer b", "Bearer a"} {
+		req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+		req.Header.Set("Authorization", auth)
+		resp, err := c.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if string(b) != auth {
+			t.Fatalf("got %q for %q", b, auth)
+		}
+	}
+	if requests != 2 {
+		t.Fatalf("expected 2 requests (one per credential), got %d", requests)
+	}
+}
+
+func TestCache_KeyedByExtraHeaders(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	for _, headers := range []map[string]string{
+		{"X-Tenant": "a"},
+		{"X-Tenant": "b"},
+		{"x-tenant": "a"},
+		nil,
+	} {
+		req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+		resp, err := New(srv.Client(), dir, time.Hour, headers).Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+	}
+	if requests != 3 {
+		t.Fatalf("expected 3 requests (one per distinct header set), got %d", requests)
+	}
+}
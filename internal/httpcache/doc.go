@@ -0,0 +1,7 @@
+// Package httpcache implements a small on-disk cache for GrowthBook GET responses.
+//
+// Entries are keyed by the full request URL (base URL, project, client key and paging parameters),
+// the credentials and the configured extra headers, so different keys or headers never share entries. Fresh entries (younger than the TTL)
+// are served without a request; stale entries are revalidated with If-None-Match / If-Modified-Since
+// when the server provided an ETag or Last-Modified header.
+package httpcache
@@ -7,6 +7,8 @@ OPENAPI_DIR="packages/back-end/src/api/openapi"
 OUT_FILE="internal/growthbookapi/gen.go"
 PKG_NAME="growthbookapi"
 TMP_DIR=".tmp"
+# Operations used by the generator; passed to the prefilter and, as GEN_OPERATION_IDS, to oapi-codegen in ${OUT_FILE}.
+OPERATIONS="listFeatures,listSavedGroups,listAttributes,listExperiments,listEnvironments,listProjects"
 
 SCRIPT_DIR="$(cd -- "$(dirname -- "${BASH_SOURCE[0]}")" && pwd)"
 PROJECT_ROOT="$(cd -- "${SCRIPT_DIR}/.." && pwd)"
@@ -36,7 +38,11 @@ curl -o ${TMP_DIR}/openapi.yaml https://api.growthbook.io/api/v1/openapi.yaml
 echo "==> Patching OpenAPI doc to make compatible with oapi-codegen"
 yq -i '.components.schemas.PaginationFields.properties.nextOffset |= {"type":"integer", "nullable":true}' ${TMP_DIR}/openapi.yaml
 
-
+echo "==> Pruning OpenAPI doc to the operations gbgen uses"
+(cd "${PROJECT_ROOT}/tools/openapi_prefilter" && go run . \
+  -in "${PROJECT_ROOT}/${TMP_DIR}/openapi.yaml" \
+  -out "${PROJECT_ROOT}/${TMP_DIR}/openapi.min.yaml" \
+  -operation "${OPERATIONS}")
 
 echo "==> Generating Go client via oapi-codegen"
-GEN_OPENAPI_FILE=${PROJECT_ROOT}/${TMP_DIR}/openapi.yaml go generate ${OUT_FILE}
\ No newline at end of file
+GEN_OPENAPI_FILE=${PROJECT_ROOT}/${TMP_DIR}/openapi.min.yaml GEN_OPERATION_IDS=${OPERATIONS} go generate ${OUT_FILE}
\ No newline at end of file
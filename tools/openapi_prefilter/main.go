@@ -3,7 +3,9 @@ package main
 import (
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"reflect"
 	"sort"
 	"strings"
 
@@ -21,16 +23,36 @@ var httpMethods = map[string]struct{}{
 	"trace":   {},
 }
 
+// stringList is a repeatable flag; each value may also be a comma-separated list.
+type stringList []string
+
+func (l *stringList) String() string { return strings.Join(*l, ",") }
+
+func (l *stringList) Set(v string) error {
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			*l = append(*l, s)
+		}
+	}
+	return nil
+}
+
 func main() {
+	var tags, operations stringList
 	inPath := flag.String("in", "", "input OpenAPI YAML (bundled)")
 	outPath := flag.String("out", "", "output OpenAPI YAML (minimized)")
-	tag := flag.String("tag", "features", "only keep operations with this OpenAPI tag")
+	flag.Var(&tags, "tag", "keep operations with this OpenAPI tag (repeatable; default features unless -operation is set)")
+	flag.Var(&operations, "operation", "keep the operation with this operationId (repeatable)")
+	quiet := flag.Bool("quiet", false, "do not print the kept/pruned report")
 	flag.Parse()
 
 	if *inPath == "" || *outPath == "" {
 		fmt.Fprintln(os.Stderr, "ERROR: -in and -out are required")
 		os.Exit(2)
 	}
+	if len(tags) == 0 && len(operations) == 0 {
+		tags = stringList{"features"}
+	}
 
 	inBytes, err := os.ReadFile(*inPath)
 	must(err)
@@ -44,7 +66,12 @@ func main() {
 		os.Exit(1)
 	}
 
-	filteredPaths := filterPathsByTag(pathsAny, *tag)
+	sel := selector{tags: tags, operations: operations}
+	filteredPaths := filterPaths(pathsAny, sel)
+	if missing := sel.missingOperations(pathsAny); len(missing) > 0 {
+		fmt.Fprintf(os.Stderr, "ERROR: unknown operationId(s): %s\n", strings.Join(missing, ", "))
+		os.Exit(1)
+	}
 
 	// Build minimized document skeleton.
 	minDoc := map[string]any{}
@@ -54,12 +81,13 @@ func main() {
 	copyKeyIfPresent(minDoc, doc, "security")
 	minDoc["paths"] = filteredPaths
 
-	// Keep only the matching tag metadata (nice-to-have).
+	// Keep only the tag metadata of kept operations (nice-to-have).
 	if tagsAny, ok := doc["tags"].([]any); ok {
+		used := operationTags(filteredPaths)
 		var kept []any
 		for _, t := range tagsAny {
 			if m, ok := t.(map[string]any); ok {
-				if name, _ := m["name"].(string); name == *tag {
+				if name, _ := m["name"].(string); used[name] {
 					kept = append(kept, m)
 				}
 			}
@@ -77,17 +105,56 @@ func main() {
 
 	// Some upstream schemas define enums inline on properties (e.g. Feature.valueType).
 	// The Go openapi-generator commonly does NOT emit a dedicated enum type + constants for
-	// inline property enums, but it does for named schemas. Promote the known inline enum
+	// inline property enums, but it does for named schemas. Promote every inline enum
 	// into a named component schema and replace inline definitions with a $ref across the
 	// entire minimized document (including inline request/response body schemas).
-	promoteValueTypeEnum(minDoc)
+	promoted := promoteInlineEnums(minDoc)
 
 	outBytes, err := yaml.Marshal(minDoc)
 	must(err)
 	must(os.WriteFile(*outPath, outBytes, 0o644))
+
+	if !*quiet {
+		writeReport(os.Stdout, pathsAny, filteredPaths, componentsAny, minComponents, promoted)
+	}
+}
+
+// selector decides which operations are kept: those with any of the tags, plus those listed by operationId.
+type selector struct {
+	tags       []string
+	operations []string
+}
+
+func (s selector) keep(op map[string]any) bool {
+	if id, _ := op["operationId"].(string); id != "" && contains(s.operations, id) {
+		return true
+	}
+	for _, tag := range s.tags {
+		if operationHasTag(op, tag) {
+			return true
+		}
+	}
+	return false
 }
 
-func filterPathsByTag(paths map[string]any, tag string) map[string]any {
+// missingOperations returns the requested operationIds that the input document does not define.
+func (s selector) missingOperations(paths map[string]any) []string {
+	defined := map[string]bool{}
+	forEachOperation(paths, func(_, _ string, op map[string]any) {
+		if id, _ := op["operationId"].(string); id != "" {
+			defined[id] = true
+		}
+	})
+	var missing []string
+	for _, id := range s.operations {
+		if !defined[id] {
+			missing = append(missing, id)
+		}
+	}
+	return missing
+}
+
+func filterPaths(paths map[string]any, sel selector) map[string]any {
 	out := map[string]any{}
 	for p, v := range paths {
 		pathItem, ok := v.(map[string]any)
@@ -108,11 +175,12 @@ func filterPathsByTag(paths map[string]any, tag string) map[string]any {
 			if !ok {
 				continue
 			}
-			if operationHasTag(op, tag) {
+			if sel.keep(op) {
 				newPathItem[lk] = op
 			}
 		}
-		if len(newPathItem) > 0 {
+		// Path-level parameters alone do not make a path worth keeping.
+		if len(newPathItem) > 1 || (len(newPathItem) == 1 && newPathItem["parameters"] == nil) {
 			out[p] = newPathItem
 		}
 	}
@@ -132,6 +200,38 @@ func operationHasTag(op map[string]any, tag string) bool {
 	return false
 }
 
+// forEachOperation calls fn for every operation, in path and method order.
+func forEachOperation(paths map[string]any, fn func(path, method string, op map[string]any)) {
+	for _, p := range sortedKeys(paths) {
+		pathItem, ok := paths[p].(map[string]any)
+		if !ok {
+			continue
+		}
+		for _, k := range sortedKeys(pathItem) {
+			lk := strings.ToLower(k)
+			if _, isMethod := httpMethods[lk]; !isMethod {
+				continue
+			}
+			if op, ok := pathItem[k].(map[string]any); ok {
+				fn(p, lk, op)
+			}
+		}
+	}
+}
+
+func operationTags(paths map[string]any) map[string]bool {
+	out := map[string]bool{}
+	forEachOperation(paths, func(_, _ string, op map[string]any) {
+		tagsAny, _ := op["tags"].([]any)
+		for _, t := range tagsAny {
+			if s, ok := t.(string); ok {
+				out[s] = true
+			}
+		}
+	})
+	return out
+}
+
 func buildMinComponents(filteredPaths map[string]any, components map[string]any, fullDoc map[string]any) map[string]any {
 	if len(components) == 0 {
 		return map[string]any{}
@@ -315,10 +415,22 @@ func must(err error) {
 	}
 }
 
-func promoteValueTypeEnum(doc map[string]any) {
+// promotedEnum records an inline enum moved to a named component schema.
+type promotedEnum struct {
+	Name string
+	// Sources are the locations the enum was inlined at, e.g. "Feature.valueType".
+	Sources []string
+}
+
+// promoteInlineEnums moves every inline enum in the document into a named component schema and replaces the
+// inline definitions with a $ref. Enums inside component schemas are named after the schema and the property path
+// (Feature.valueType becomes FeatureValueType), the way oapi-codegen names inline enums; enums elsewhere reuse a
+// promoted schema with the same property name and values, or are named after the property. Existing component
+// enums are left alone.
+func promoteInlineEnums(doc map[string]any) []promotedEnum {
 	components, ok := doc["components"].(map[string]any)
 	if !ok || len(components) == 0 {
-		return
+		return nil
 	}
 	schemas, _ := components["schemas"].(map[string]any)
 	if schemas == nil {
@@ -326,119 +438,197 @@ func promoteValueTypeEnum(doc map[string]any) {
 		components["schemas"] = schemas
 	}
 
-	// If the schema already exists, just rewrite inline occurrences to $ref it.
-	const enumSchemaName = "FeatureValueType"
-	ref := "#/components/schemas/" + enumSchemaName
-
-	var enumVals []string
-	if existing, ok := schemas[enumSchemaName].(map[string]any); ok {
-		enumVals = enumStrings(existing)
+	p := &enumPromoter{
+		schemas:       schemas,
+		byName:        map[string]*promotedEnum{},
+		promotedNames: map[string]bool{},
+		byProperty:    map[string][]string{},
 	}
-
-	// Discover enum values from any inline `properties.valueType` enum in the minimized doc.
-	if len(enumVals) == 0 {
-		enumVals = findInlineValueTypeEnumValues(doc)
+	for _, name := range sortedKeys(schemas) {
+		schema, ok := schemas[name].(map[string]any)
+		if !ok {
+			continue
+		}
+		p.walk(schema, name, name)
 	}
-
-	// Nothing to do.
-	if len(enumVals) == 0 {
-		return
+	if paths, ok := doc["paths"].(map[string]any); ok {
+		forEachOperation(paths, func(path, method string, op map[string]any) {
+			p.walk(op, "", method+" "+path)
+		})
 	}
-
-	// Ensure component schema exists (so generators can emit a named enum type + constants).
-	if _, ok := schemas[enumSchemaName]; !ok {
-		schemas[enumSchemaName] = map[string]any{
-			"type":        "string",
-			"description": "The data type of the feature payload.",
-			"enum":        stringSliceToAny(enumVals),
+	for _, section := range sortedKeys(components) {
+		if section == "schemas" {
+			continue
 		}
+		p.walk(components[section], "", section)
+	}
+
+	out := make([]promotedEnum, 0, len(p.order))
+	for _, name := range p.order {
+		out = append(out, *p.byName[name])
 	}
+	return out
+}
 
-	// Rewrite every inline properties.valueType enum in the minimized doc to be a $ref.
-	rewriteInlineValueTypeEnums(doc, ref)
+type enumPromoter struct {
+	schemas       map[string]any
+	byName        map[string]*promotedEnum
+	promotedNames map[string]bool
+	// byProperty maps a property name to the schemas promoted for it, for reuse outside component schemas.
+	byProperty map[string][]string
+	order      []string
 }
 
-func findInlineValueTypeEnumValues(doc map[string]any) []string {
-	var found []string
-	var walk func(any)
-	walk = func(v any) {
-		if len(found) > 0 {
+// walk scans v for inline enums. prefix names the schema being walked (e.g. "FeatureForceRuleSavedGroupTargeting";
+// "" outside component schemas) and at describes the location for the report.
+func (p *enumPromoter) walk(v any, prefix, at string) {
+	switch t := v.(type) {
+	case map[string]any:
+		for _, k := range sortedKeys(t) {
+			if k != "properties" {
+				p.walk(t[k], prefix, at)
+			}
+		}
+		props, ok := t["properties"].(map[string]any)
+		if !ok {
 			return
 		}
-		switch t := v.(type) {
-		case map[string]any:
-			if props, ok := t["properties"].(map[string]any); ok {
-				if vtAny, ok := props["valueType"].(map[string]any); ok {
-					if typ, _ := vtAny["type"].(string); typ == "string" {
-						if enums := enumStrings(vtAny); len(enums) > 0 {
-							found = enums
-							return
-						}
-					}
-				}
+		for _, prop := range sortedKeys(props) {
+			propSchema, ok := props[prop].(map[string]any)
+			if !ok {
+				continue
 			}
-			for _, vv := range t {
-				walk(vv)
-				if len(found) > 0 {
-					return
-				}
+			name := prefix + upperFirst(prop)
+			if isInlineEnum(propSchema) {
+				props[prop] = map[string]any{"$ref": "#/components/schemas/" + p.promote(propSchema, name, prefix == "", prop, at+"."+prop)}
+				continue
 			}
-		case []any:
-			for _, vv := range t {
-				walk(vv)
-				if len(found) > 0 {
-					return
-				}
+			if items, ok := propSchema["items"].(map[string]any); ok && isInlineEnum(items) {
+				propSchema["items"] = map[string]any{"$ref": "#/components/schemas/" + p.promote(items, name+"Item", prefix == "", prop, at+"."+prop+"[]")}
+				continue
 			}
+			p.walk(propSchema, name, at+"."+prop)
+		}
+	case []any:
+		for _, vv := range t {
+			p.walk(vv, prefix, at)
 		}
 	}
-	walk(doc)
-	return found
 }
 
-func rewriteInlineValueTypeEnums(v any, ref string) {
-	switch t := v.(type) {
-	case map[string]any:
-		if props, ok := t["properties"].(map[string]any); ok && props != nil {
-			if vtAny, ok := props["valueType"].(map[string]any); ok {
-				if typ, _ := vtAny["type"].(string); typ == "string" && len(enumStrings(vtAny)) > 0 {
-					props["valueType"] = map[string]any{"$ref": ref}
-				}
+// promote returns the name of the component schema for an inline enum, creating it if needed. Enums outside
+// component schemas (reuse) first look for an identical enum promoted for the same property.
+func (p *enumPromoter) promote(schema map[string]any, base string, reuse bool, prop, at string) string {
+	if reuse {
+		for _, name := range p.byProperty[prop] {
+			if sameEnum(p.schemas[name], schema) {
+				p.byName[name].Sources = append(p.byName[name].Sources, at)
+				return name
 			}
 		}
-		for _, vv := range t {
-			rewriteInlineValueTypeEnums(vv, ref)
+	}
+
+	name := base
+	for i := 2; ; i++ {
+		existing, taken := p.schemas[name]
+		if !taken {
+			break
 		}
-	case []any:
-		for _, vv := range t {
-			rewriteInlineValueTypeEnums(vv, ref)
+		if p.promotedNames[name] && sameEnum(existing, schema) {
+			p.byName[name].Sources = append(p.byName[name].Sources, at)
+			return name
 		}
+		name = fmt.Sprintf("%s%d", base, i)
 	}
+
+	p.schemas[name] = schema
+	p.promotedNames[name] = true
+	p.byProperty[prop] = append(p.byProperty[prop], name)
+	p.byName[name] = &promotedEnum{Name: name, Sources: []string{at}}
+	p.order = append(p.order, name)
+	return name
 }
 
-func enumStrings(schema map[string]any) []string {
+func isInlineEnum(schema map[string]any) bool {
+	if _, isRef := schema["$ref"]; isRef {
+		return false
+	}
 	enumAny, ok := schema["enum"].([]any)
-	if !ok || len(enumAny) == 0 {
-		return nil
+	return ok && len(enumAny) > 0
+}
+
+func sameEnum(a any, b map[string]any) bool {
+	am, ok := a.(map[string]any)
+	return ok && am["type"] == b["type"] && reflect.DeepEqual(am["enum"], b["enum"])
+}
+
+func upperFirst(s string) string {
+	if s == "" {
+		return s
 	}
-	out := make([]string, 0, len(enumAny))
-	for _, v := range enumAny {
-		s, ok := v.(string)
-		if !ok || s == "" {
-			continue
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// writeReport lists kept and pruned operations and components, and promoted enums, so a regenerated client
+// can be reviewed.
+func writeReport(w io.Writer, allPaths, keptPaths, allComponents, keptComponents map[string]any, promoted []promotedEnum) {
+	var keptOps, prunedOps []string
+	forEachOperation(allPaths, func(path, method string, op map[string]any) {
+		id, _ := op["operationId"].(string)
+		line := fmt.Sprintf("%s %s (%s)", strings.ToUpper(method), path, id)
+		if kept, ok := keptPaths[path].(map[string]any); ok && kept[method] != nil {
+			keptOps = append(keptOps, line)
+		} else {
+			prunedOps = append(prunedOps, line)
+		}
+	})
+
+	var keptComps, prunedComps []string
+	for _, section := range sortedKeys(allComponents) {
+		all, _ := allComponents[section].(map[string]any)
+		kept, _ := keptComponents[section].(map[string]any)
+		for _, name := range sortedKeys(all) {
+			if _, ok := kept[name]; ok {
+				keptComps = append(keptComps, section+"/"+name)
+			} else {
+				prunedComps = append(prunedComps, section+"/"+name)
+			}
 		}
-		out = append(out, s)
 	}
-	if len(out) == 0 {
-		return nil
+
+	var promotedLines []string
+	for _, e := range promoted {
+		promotedLines = append(promotedLines, fmt.Sprintf("schemas/%s <- %s", e.Name, strings.Join(e.Sources, ", ")))
 	}
-	return out
+
+	writeSection(w, "operations kept", keptOps)
+	writeSection(w, "operations pruned", prunedOps)
+	writeSection(w, "components kept", keptComps)
+	writeSection(w, "components pruned", prunedComps)
+	writeSection(w, "enums promoted", promotedLines)
 }
 
-func stringSliceToAny(in []string) []any {
-	out := make([]any, 0, len(in))
-	for _, s := range in {
-		out = append(out, s)
+func writeSection(w io.Writer, title string, lines []string) {
+	fmt.Fprintf(w, "%s (%d):\n", title, len(lines))
+	for _, l := range lines {
+		fmt.Fprintf(w, "  %s\n", l)
 	}
-	return out
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
 }